@@ -12,16 +12,10 @@ func WithAPIKey(apiKey string) ClientOption {
 			return fmt.Errorf("no api key provided")
 		}
 
-		currentTransport := c.httpClient.Transport
-		if currentTransport == nil {
-			currentTransport = http.DefaultTransport
-		}
-
-		c.httpClient.Transport = &authHeaderTransport{
-			name:      "X-Api-Key",
-			value:     apiKey,
-			transport: currentTransport,
-		}
+		c.authHeaders = append(c.authHeaders, authHeaderTransport{
+			name:  "X-Api-Key",
+			value: apiKey,
+		})
 
 		return nil
 	}
@@ -33,16 +27,10 @@ func WithBearerToken(token string) ClientOption {
 			return fmt.Errorf("no token provided")
 		}
 
-		currentTransport := c.httpClient.Transport
-		if currentTransport == nil {
-			currentTransport = http.DefaultTransport
-		}
-
-		c.httpClient.Transport = &authHeaderTransport{
-			name:      "Authorization",
-			value:     fmt.Sprintf("Bearer %s", token),
-			transport: currentTransport,
-		}
+		c.authHeaders = append(c.authHeaders, authHeaderTransport{
+			name:  "Authorization",
+			value: fmt.Sprintf("Bearer %s", token),
+		})
 
 		return nil
 	}
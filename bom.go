@@ -2,10 +2,16 @@ package dtrack
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/nscuro/dtrack-client/bom"
 )
 
 type BOMService struct {
@@ -17,7 +23,8 @@ type BOMUploadRequest struct {
 	ProjectName    string     `json:"projectName,omitempty"`
 	ProjectVersion string     `json:"projectVersion,omitempty"`
 	AutoCreate     bool       `json:"autoCreate"`
-	BOM            string     `json:"bom"`
+	BOM            string     `json:"bom"` // Base64 encoded BOM
+	Validate       bool       `json:"-"`   // Whether to validate the BOM locally before uploading it
 }
 
 type bomUploadResponse struct {
@@ -77,7 +84,70 @@ func (bs BOMService) ExportProject(ctx context.Context, projectUUID uuid.UUID, f
 	return
 }
 
+// ExportProjectTo is like ExportProject, but streams the exported BOM to w instead of
+// reading it into memory. It returns the number of bytes written, and the content type
+// reported by the server.
+func (bs BOMService) ExportProjectTo(ctx context.Context, projectUUID uuid.UUID, format BOMFormat, variant BOMVariant, w io.Writer) (written int64, contentType string, err error) {
+	params := make(map[string]string)
+	if format != "" {
+		params["format"] = string(format)
+	}
+	if variant != "" {
+		params["variant"] = string(variant)
+	}
+
+	req, err := bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/cyclonedx/project/%s", projectUUID), withParams(params))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Accept", cycloneDXMediaType(format))
+
+	rw := responseWriter{w: w}
+	_, err = bs.client.doRequest(req, &rw)
+	written, contentType = rw.written, rw.contentType
+	return
+}
+
+// cycloneDXMediaType returns the media type of CycloneDX documents in the given format.
+func cycloneDXMediaType(format BOMFormat) string {
+	if format == BOMFormatXML {
+		return "application/vnd.cyclonedx+xml"
+	}
+
+	return "application/vnd.cyclonedx+json"
+}
+
+// ExportComponentDocument is like ExportComponent, but decodes the exported BOM into a bom.Document.
+func (bs BOMService) ExportComponentDocument(ctx context.Context, componentUUID uuid.UUID, format BOMFormat) (doc bom.Document, err error) {
+	exported, err := bs.ExportComponent(ctx, componentUUID, format)
+	if err != nil {
+		return
+	}
+
+	doc, _, err = bom.Decode([]byte(exported))
+	return
+}
+
+// ExportProjectDocument is like ExportProject, but decodes the exported BOM into a bom.Document.
+func (bs BOMService) ExportProjectDocument(ctx context.Context, projectUUID uuid.UUID, format BOMFormat, variant BOMVariant) (doc bom.Document, err error) {
+	exported, err := bs.ExportProject(ctx, projectUUID, format, variant)
+	if err != nil {
+		return
+	}
+
+	doc, _, err = bom.Decode([]byte(exported))
+	return
+}
+
 func (bs BOMService) Upload(ctx context.Context, uploadReq BOMUploadRequest) (token BOMUploadToken, err error) {
+	if uploadReq.Validate {
+		err = validateBOM(uploadReq.BOM)
+		if err != nil {
+			return
+		}
+	}
+
 	req, err := bs.client.newRequest(ctx, http.MethodPut, "/api/v1/bom", withBody(uploadReq))
 	if err != nil {
 		return
@@ -93,10 +163,26 @@ func (bs BOMService) Upload(ctx context.Context, uploadReq BOMUploadRequest) (to
 	return
 }
 
+// validateBOM validates a base64 encoded BOM using bom.Validate.
+func validateBOM(encodedBOM string) error {
+	content, err := base64.StdEncoding.DecodeString(encodedBOM)
+	if err != nil {
+		return fmt.Errorf("failed to decode bom: %w", err)
+	}
+
+	if _, err = bom.Validate(content); err != nil {
+		return fmt.Errorf("bom validation failed: %w", err)
+	}
+
+	return nil
+}
+
 type bomProcessingResponse struct {
 	Processing bool `json:"processing"`
 }
 
+// IsBeingProcessed determines whether the BOM identified by token is still being processed.
+// Dependency-Track 4.11 deprecated the underlying endpoint in favor of EventService.IsBeingProcessed.
 func (bs BOMService) IsBeingProcessed(ctx context.Context, token BOMUploadToken) (bool, error) {
 	req, err := bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/token/%s", token))
 	if err != nil {
@@ -111,3 +197,211 @@ func (bs BOMService) IsBeingProcessed(ctx context.Context, token BOMUploadToken)
 
 	return processingResponse.Processing, nil
 }
+
+// BOMProcessingStatus is a status update emitted while waiting for a BOM to be processed.
+type BOMProcessingStatus struct {
+	Token      BOMUploadToken
+	Processing bool          // Whether the BOM is still being processed
+	Polls      int           // Number of times the processing status has been polled so far
+	Elapsed    time.Duration // Time elapsed since the upload
+}
+
+// WaitOptions controls how UploadAndWait waits for a BOM to be processed.
+type WaitOptions struct {
+	PollInterval      time.Duration              // Interval before the first poll; defaults to 1s
+	MaxPollInterval   time.Duration              // Upper bound for the poll interval; defaults to PollInterval
+	BackoffFactor     float64                    // Factor the poll interval grows by after every poll; defaults to 1
+	Status            chan<- BOMProcessingStatus // Receives status updates if not nil; never closed, so it may be reused across calls
+	IncludeSuppressed bool                       // Whether to include suppressed findings in the result
+}
+
+// BOMUploadResult is the outcome of UploadAndWait.
+type BOMUploadResult struct {
+	Token    BOMUploadToken
+	Project  Project
+	Findings []Finding
+	Metrics  ProjectMetrics
+}
+
+// UploadAndWait uploads a BOM, and waits for its processing to complete.
+// Once completed, the project's findings and latest metrics are fetched.
+//
+// The processing status is polled using EventService.IsBeingProcessed if the server supports it,
+// and using BOMService.IsBeingProcessed otherwise.
+//
+// Waiting ends when ctx is done. The returned result will always contain
+// the upload token if the upload succeeded, even if an error occurred afterwards.
+//
+// Status updates are sent to WaitOptions.Status, blocking until they're received or ctx is done.
+// The channel is owned by the caller, and is not closed by UploadAndWait.
+func (bs BOMService) UploadAndWait(ctx context.Context, uploadReq BOMUploadRequest, opts WaitOptions) (result BOMUploadResult, err error) {
+	uploadedAt := time.Now()
+	result.Token, err = bs.Upload(ctx, uploadReq)
+	if err != nil {
+		return
+	}
+
+	err = bs.waitForProcessing(ctx, result.Token, uploadedAt, opts)
+	if err != nil {
+		return
+	}
+
+	if uploadReq.ProjectUUID != nil {
+		result.Project, err = bs.client.Project.Get(ctx, *uploadReq.ProjectUUID)
+	} else {
+		result.Project, err = bs.client.Project.Lookup(ctx, uploadReq.ProjectName, uploadReq.ProjectVersion)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to fetch project: %w", err)
+		return
+	}
+
+	result.Findings, err = FetchAll(func(po PageOptions) (Page[Finding], error) {
+		return bs.client.Finding.GetAll(ctx, result.Project.UUID, opts.IncludeSuppressed, po)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to fetch findings: %w", err)
+		return
+	}
+
+	result.Metrics, err = bs.client.Metrics.LatestProjectMetrics(ctx, result.Project.UUID)
+	if err != nil {
+		err = fmt.Errorf("failed to fetch metrics: %w", err)
+		return
+	}
+
+	return
+}
+
+func (bs BOMService) waitForProcessing(ctx context.Context, token BOMUploadToken, uploadedAt time.Time, opts WaitOptions) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxPollInterval
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	backoffFactor := opts.BackoffFactor
+	if backoffFactor < 1 {
+		backoffFactor = 1
+	}
+
+	serverVersion, err := bs.client.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Prefer the event token endpoint, and only fall back to the deprecated BOM token endpoint on older servers.
+	isBeingProcessed := func() (bool, error) {
+		return bs.client.Event.IsBeingProcessed(ctx, EventToken(token))
+	}
+	if !serverVersion.AtLeast(minVersionEventToken) {
+		isBeingProcessed = func() (bool, error) {
+			return bs.IsBeingProcessed(ctx, token)
+		}
+	}
+
+	for polls := 1; ; polls++ {
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+
+		processing, err := isBeingProcessed()
+		if err != nil {
+			return fmt.Errorf("failed to poll processing status: %w", err)
+		}
+
+		if opts.Status != nil {
+			status := BOMProcessingStatus{
+				Token:      token,
+				Processing: processing,
+				Polls:      polls,
+				Elapsed:    time.Since(uploadedAt),
+			}
+
+			select {
+			case opts.Status <- status:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if !processing {
+			return nil
+		}
+
+		interval = time.Duration(float64(interval) * backoffFactor)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// BOMUploadOptions describes the project a BOM uploaded via UploadReader belongs to.
+type BOMUploadOptions struct {
+	ProjectUUID    *uuid.UUID
+	ProjectName    string
+	ProjectVersion string
+	AutoCreate     bool
+	ParentUUID     *uuid.UUID // Parent of the project to create; requires AutoCreate and Dependency-Track 4.8 or newer
+	ParentName     string     // Name of the parent; alternative to ParentUUID
+	ParentVersion  string     // Version of the parent; alternative to ParentUUID
+	Gzip           bool       // Whether to compress the request; requires the server to accept gzip encoded requests
+}
+
+// minVersionBOMUploadParent is the minimum version of Dependency-Track that accepts
+// the parent project to be specified when uploading a BOM.
+var minVersionBOMUploadParent = Version{Major: 4, Minor: 8}
+
+// UploadReader uploads the BOM read from r.
+//
+// Unlike Upload, the BOM is neither base64 encoded nor buffered in memory,
+// but streamed to the server as multipart form. Because the request body
+// can't be rewound, the upload is never retried.
+func (bs BOMService) UploadReader(ctx context.Context, opts BOMUploadOptions, r io.Reader) (token BOMUploadToken, err error) {
+	if opts.ParentUUID != nil || opts.ParentName != "" || opts.ParentVersion != "" {
+		err = bs.client.requireServerVersion(ctx, minVersionBOMUploadParent)
+		if err != nil {
+			return
+		}
+	}
+
+	fields := make(map[string]string)
+	if opts.ProjectUUID != nil {
+		fields["project"] = opts.ProjectUUID.String()
+	}
+	if opts.ProjectName != "" {
+		fields["projectName"] = opts.ProjectName
+	}
+	if opts.ProjectVersion != "" {
+		fields["projectVersion"] = opts.ProjectVersion
+	}
+	fields["autoCreate"] = strconv.FormatBool(opts.AutoCreate)
+	if opts.ParentUUID != nil {
+		fields["parentUUID"] = opts.ParentUUID.String()
+	}
+	if opts.ParentName != "" {
+		fields["parentName"] = opts.ParentName
+	}
+	if opts.ParentVersion != "" {
+		fields["parentVersion"] = opts.ParentVersion
+	}
+
+	body := newMultipartBody(fields, "bom", r, opts.Gzip)
+	defer body.Close()
+
+	req, err := bs.client.newRequest(ctx, http.MethodPost, "/api/v1/bom", withMultipartBody(body))
+	if err != nil {
+		return
+	}
+
+	var uploadRes bomUploadResponse
+	_, err = bs.client.doRequest(req, &uploadRes)
+	if err != nil {
+		return
+	}
+
+	token = uploadRes.Token
+	return
+}
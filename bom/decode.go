@@ -0,0 +1,75 @@
+package bom
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "JSON"
+	FormatXML  Format = "XML"
+)
+
+// ErrUnknownFormat indicates that the format of a document could not be detected.
+var ErrUnknownFormat = errors.New("unknown bom format")
+
+var utf8ByteOrderMark = []byte{0xEF, 0xBB, 0xBF}
+
+// Normalize removes a leading UTF-8 byte order mark, as well as
+// leading and trailing whitespace from a document.
+func Normalize(data []byte) []byte {
+	data = bytes.TrimPrefix(data, utf8ByteOrderMark)
+	return bytes.TrimSpace(data)
+}
+
+// DetectFormat detects whether a document is encoded in JSON or XML.
+func DetectFormat(data []byte) (Format, error) {
+	data = Normalize(data)
+	if len(data) == 0 {
+		return "", fmt.Errorf("%w: document is empty", ErrUnknownFormat)
+	}
+
+	switch data[0] {
+	case '{':
+		return FormatJSON, nil
+	case '<':
+		return FormatXML, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Decode decodes a CycloneDX document in either JSON or XML format.
+//
+// For XML documents, BOMFormat and SpecVersion are derived from the document's namespace.
+// BOMFormat remains empty if the namespace is not a CycloneDX namespace.
+func Decode(data []byte) (doc Document, format Format, err error) {
+	data = Normalize(data)
+
+	format, err = DetectFormat(data)
+	if err != nil {
+		return
+	}
+
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(data, &doc)
+	case FormatXML:
+		err = xml.Unmarshal(data, &doc)
+		if err == nil && strings.HasPrefix(doc.XMLName.Space, xmlNamespacePrefix) {
+			doc.BOMFormat = BOMFormat
+			doc.SpecVersion = strings.TrimPrefix(doc.XMLName.Space, xmlNamespacePrefix)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to decode %s document: %w", format, err)
+	}
+
+	return
+}
@@ -0,0 +1,9 @@
+// Package bom provides a model of CycloneDX documents, as well as the functionality
+// to decode and validate them locally, before they're uploaded to Dependency-Track.
+//
+// Dependency-Track accepts malformed BOMs and only fails to process them asynchronously,
+// after the upload succeeded. Validating a BOM before uploading it surfaces such issues early.
+//
+// The model only covers the parts of the CycloneDX specification that are relevant
+// for working with Dependency-Track. See https://cyclonedx.org/specification/overview/.
+package bom
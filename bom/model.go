@@ -0,0 +1,248 @@
+package bom
+
+import (
+	"encoding/xml"
+)
+
+const (
+	BOMFormat = "CycloneDX"
+
+	// xmlNamespacePrefix is the prefix of the namespaces of CycloneDX XML documents.
+	// It is followed by the spec version, e.g. "http://cyclonedx.org/schema/bom/1.4".
+	xmlNamespacePrefix = "http://cyclonedx.org/schema/bom/"
+)
+
+type Document struct {
+	XMLName         xml.Name        `json:"-" xml:"bom"`
+	BOMFormat       string          `json:"bomFormat" xml:"-"`
+	SpecVersion     string          `json:"specVersion" xml:"-"`
+	SerialNumber    string          `json:"serialNumber,omitempty" xml:"serialNumber,attr,omitempty"`
+	Version         int             `json:"version,omitempty" xml:"version,attr,omitempty"`
+	Metadata        *Metadata       `json:"metadata,omitempty" xml:"metadata,omitempty"`
+	Components      []Component     `json:"components,omitempty" xml:"components>component,omitempty"`
+	Services        []Service       `json:"services,omitempty" xml:"services>service,omitempty"`
+	Dependencies    []Dependency    `json:"dependencies,omitempty" xml:"dependencies>dependency,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty" xml:"vulnerabilities>vulnerability,omitempty"`
+}
+
+type Metadata struct {
+	Timestamp string     `json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	Component *Component `json:"component,omitempty" xml:"component,omitempty"`
+}
+
+type Component struct {
+	BOMRef             string              `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Type               string              `json:"type" xml:"type,attr"`
+	Author             string              `json:"author,omitempty" xml:"author,omitempty"`
+	Publisher          string              `json:"publisher,omitempty" xml:"publisher,omitempty"`
+	Group              string              `json:"group,omitempty" xml:"group,omitempty"`
+	Name               string              `json:"name" xml:"name"`
+	Version            string              `json:"version,omitempty" xml:"version,omitempty"`
+	Description        string              `json:"description,omitempty" xml:"description,omitempty"`
+	Scope              string              `json:"scope,omitempty" xml:"scope,omitempty"`
+	Hashes             []Hash              `json:"hashes,omitempty" xml:"hashes>hash,omitempty"`
+	Licenses           Licenses            `json:"licenses,omitempty" xml:"licenses,omitempty"`
+	Copyright          string              `json:"copyright,omitempty" xml:"copyright,omitempty"`
+	CPE                string              `json:"cpe,omitempty" xml:"cpe,omitempty"`
+	PURL               string              `json:"purl,omitempty" xml:"purl,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty" xml:"externalReferences>reference,omitempty"`
+	Properties         []Property          `json:"properties,omitempty" xml:"properties>property,omitempty"`
+	Components         []Component         `json:"components,omitempty" xml:"components>component,omitempty"`
+}
+
+type Hash struct {
+	Algorithm string `json:"alg" xml:"alg,attr"`
+	Value     string `json:"content" xml:",chardata"`
+}
+
+type License struct {
+	ID   string `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+	URL  string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+// LicenseChoice is either a License, or an SPDX license expression.
+type LicenseChoice struct {
+	License    *License `json:"license,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
+// Licenses is a list of LicenseChoice.
+// In XML, licenses and expressions are sibling elements of a licenses element.
+type Licenses []LicenseChoice
+
+func (l Licenses) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(l) == 0 {
+		return nil
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, choice := range l {
+		var err error
+		if choice.License != nil {
+			err = e.EncodeElement(choice.License, xml.StartElement{Name: xml.Name{Local: "license"}})
+		} else if choice.Expression != "" {
+			err = e.EncodeElement(choice.Expression, xml.StartElement{Name: xml.Name{Local: "expression"}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (l *Licenses) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "license":
+				var license License
+				if err = dec.DecodeElement(&license, &t); err != nil {
+					return err
+				}
+				*l = append(*l, LicenseChoice{License: &license})
+			case "expression":
+				var expression string
+				if err = dec.DecodeElement(&expression, &t); err != nil {
+					return err
+				}
+				*l = append(*l, LicenseChoice{Expression: expression})
+			default:
+				if err = dec.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type ExternalReference struct {
+	Type    string `json:"type" xml:"type,attr"`
+	URL     string `json:"url" xml:"url"`
+	Comment string `json:"comment,omitempty" xml:"comment,omitempty"`
+}
+
+type Property struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value,omitempty" xml:",chardata"`
+}
+
+type Service struct {
+	BOMRef             string              `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Group              string              `json:"group,omitempty" xml:"group,omitempty"`
+	Name               string              `json:"name" xml:"name"`
+	Version            string              `json:"version,omitempty" xml:"version,omitempty"`
+	Description        string              `json:"description,omitempty" xml:"description,omitempty"`
+	Endpoints          []string            `json:"endpoints,omitempty" xml:"endpoints>endpoint,omitempty"`
+	Authenticated      *bool               `json:"authenticated,omitempty" xml:"authenticated,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty" xml:"externalReferences>reference,omitempty"`
+	Properties         []Property          `json:"properties,omitempty" xml:"properties>property,omitempty"`
+	Services           []Service           `json:"services,omitempty" xml:"services>service,omitempty"`
+}
+
+// Dependency describes the direct dependencies of the component or service referenced by Ref.
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// dependencyXML is the XML representation of Dependency.
+// In XML, direct dependencies are nested dependency elements.
+type dependencyXML struct {
+	Ref          string `xml:"ref,attr"`
+	Dependencies []struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"dependency"`
+}
+
+func (d Dependency) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	dx := dependencyXML{Ref: d.Ref}
+	for _, ref := range d.DependsOn {
+		dx.Dependencies = append(dx.Dependencies, struct {
+			Ref string `xml:"ref,attr"`
+		}{Ref: ref})
+	}
+
+	return e.EncodeElement(dx, start)
+}
+
+func (d *Dependency) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var dx dependencyXML
+	if err := dec.DecodeElement(&dx, &start); err != nil {
+		return err
+	}
+
+	*d = Dependency{Ref: dx.Ref}
+	for _, dependency := range dx.Dependencies {
+		d.DependsOn = append(d.DependsOn, dependency.Ref)
+	}
+
+	return nil
+}
+
+// Vulnerability is a vulnerability as described by the CycloneDX vulnerability extension,
+// which is part of the core specification since CycloneDX 1.4.
+type Vulnerability struct {
+	BOMRef         string                   `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	ID             string                   `json:"id,omitempty" xml:"id,omitempty"`
+	Source         *Source                  `json:"source,omitempty" xml:"source,omitempty"`
+	References     []VulnerabilityReference `json:"references,omitempty" xml:"references>reference,omitempty"`
+	Ratings        []Rating                 `json:"ratings,omitempty" xml:"ratings>rating,omitempty"`
+	CWEs           []int                    `json:"cwes,omitempty" xml:"cwes>cwe,omitempty"`
+	Description    string                   `json:"description,omitempty" xml:"description,omitempty"`
+	Detail         string                   `json:"detail,omitempty" xml:"detail,omitempty"`
+	Recommendation string                   `json:"recommendation,omitempty" xml:"recommendation,omitempty"`
+	Advisories     []Advisory               `json:"advisories,omitempty" xml:"advisories>advisory,omitempty"`
+	Created        string                   `json:"created,omitempty" xml:"created,omitempty"`
+	Published      string                   `json:"published,omitempty" xml:"published,omitempty"`
+	Updated        string                   `json:"updated,omitempty" xml:"updated,omitempty"`
+	Analysis       *VulnerabilityAnalysis   `json:"analysis,omitempty" xml:"analysis,omitempty"`
+	Affects        []Affect                 `json:"affects,omitempty" xml:"affects>target,omitempty"`
+}
+
+type Source struct {
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+	URL  string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+type VulnerabilityReference struct {
+	ID     string  `json:"id" xml:"id"`
+	Source *Source `json:"source,omitempty" xml:"source,omitempty"`
+}
+
+type Rating struct {
+	Source   *Source  `json:"source,omitempty" xml:"source,omitempty"`
+	Score    *float64 `json:"score,omitempty" xml:"score,omitempty"`
+	Severity string   `json:"severity,omitempty" xml:"severity,omitempty"`
+	Method   string   `json:"method,omitempty" xml:"method,omitempty"`
+	Vector   string   `json:"vector,omitempty" xml:"vector,omitempty"`
+}
+
+type Advisory struct {
+	Title string `json:"title,omitempty" xml:"title,omitempty"`
+	URL   string `json:"url" xml:"url"`
+}
+
+type VulnerabilityAnalysis struct {
+	State         string   `json:"state,omitempty" xml:"state,omitempty"`
+	Justification string   `json:"justification,omitempty" xml:"justification,omitempty"`
+	Responses     []string `json:"response,omitempty" xml:"responses>response,omitempty"`
+	Detail        string   `json:"detail,omitempty" xml:"detail,omitempty"`
+}
+
+// Affect references a component or service affected by a vulnerability.
+type Affect struct {
+	Ref string `json:"ref" xml:"ref"`
+}
@@ -0,0 +1,212 @@
+package bom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Problem is a single issue found while validating a document.
+type Problem struct {
+	Path    string // Location of the problem within the document, e.g. "components[2].name"
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// ValidationError is returned when a document is invalid.
+// It lists all problems found in the document.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e ValidationError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem.String())
+	}
+
+	return fmt.Sprintf("invalid bom (%d problems): %s", len(e.Problems), strings.Join(problems, "; "))
+}
+
+var (
+	// supportedSpecVersions are the CycloneDX versions supported per format.
+	// JSON is only supported since CycloneDX 1.2.
+	supportedSpecVersions = map[Format][]string{
+		FormatJSON: {"1.2", "1.3", "1.4", "1.5", "1.6"},
+		FormatXML:  {"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6"},
+	}
+
+	componentTypes = []string{
+		"application",
+		"container",
+		"cryptographic-asset",
+		"data",
+		"device",
+		"device-driver",
+		"file",
+		"firmware",
+		"framework",
+		"library",
+		"machine-learning-model",
+		"operating-system",
+		"platform",
+	}
+
+	serialNumberRegex = regexp.MustCompile(`^urn:uuid:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// Validate decodes and validates a CycloneDX document.
+//
+// Validation covers the document's format and spec version, fields required by the specification,
+// uniqueness of bom-refs, and references of dependencies. It is not a replacement for a full
+// JSON or XML schema validation.
+//
+// When the document is invalid, a *ValidationError listing all problems is returned.
+func Validate(data []byte) (format Format, err error) {
+	doc, format, err := Decode(data)
+	if err != nil {
+		return
+	}
+
+	if problems := ValidateDocument(doc, format); len(problems) > 0 {
+		err = &ValidationError{Problems: problems}
+	}
+
+	return
+}
+
+// ValidateDocument validates an already decoded document, and returns all problems found.
+func ValidateDocument(doc Document, format Format) []Problem {
+	v := validator{
+		bomRefs: make(map[string]string),
+	}
+
+	v.validateHeader(doc, format)
+
+	if doc.Metadata != nil && doc.Metadata.Component != nil {
+		v.validateComponent("metadata.component", *doc.Metadata.Component)
+	}
+	for i, component := range doc.Components {
+		v.validateComponent(fmt.Sprintf("components[%d]", i), component)
+	}
+	for i, service := range doc.Services {
+		v.validateService(fmt.Sprintf("services[%d]", i), service)
+	}
+	for i, vulnerability := range doc.Vulnerabilities {
+		v.registerBOMRef(fmt.Sprintf("vulnerabilities[%d]", i), vulnerability.BOMRef)
+	}
+
+	// Dependencies are validated last, so all bom-refs are known.
+	for i, dependency := range doc.Dependencies {
+		v.validateDependency(fmt.Sprintf("dependencies[%d]", i), dependency)
+	}
+
+	return v.problems
+}
+
+type validator struct {
+	problems []Problem
+	bomRefs  map[string]string // bom-ref -> path of the element defining it
+}
+
+func (v *validator) addProblem(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validateHeader(doc Document, format Format) {
+	if format == FormatJSON && doc.BOMFormat != BOMFormat {
+		v.addProblem("bomFormat", "must be %q, but is %q", BOMFormat, doc.BOMFormat)
+	}
+	if format == FormatXML && doc.BOMFormat == "" {
+		v.addProblem("", "document is not in a CycloneDX namespace (%q)", doc.XMLName.Space)
+	}
+
+	if doc.SpecVersion == "" {
+		v.addProblem("specVersion", "is required")
+	} else if !containsString(supportedSpecVersions[format], doc.SpecVersion) {
+		v.addProblem("specVersion", "%s is not supported for %s documents (supported: %s)",
+			doc.SpecVersion, format, strings.Join(supportedSpecVersions[format], ", "))
+	}
+
+	if doc.SerialNumber != "" && !serialNumberRegex.MatchString(doc.SerialNumber) {
+		v.addProblem("serialNumber", "must be a URN of the form urn:uuid:<uuid>, but is %q", doc.SerialNumber)
+	}
+	if doc.Version < 0 {
+		v.addProblem("version", "must not be negative")
+	}
+}
+
+func (v *validator) validateComponent(path string, component Component) {
+	if component.Type == "" {
+		v.addProblem(path+".type", "is required")
+	} else if !containsString(componentTypes, component.Type) {
+		v.addProblem(path+".type", "%q is not a valid component type", component.Type)
+	}
+	if component.Name == "" {
+		v.addProblem(path+".name", "is required")
+	}
+
+	v.registerBOMRef(path, component.BOMRef)
+
+	for i, child := range component.Components {
+		v.validateComponent(fmt.Sprintf("%s.components[%d]", path, i), child)
+	}
+}
+
+func (v *validator) validateService(path string, service Service) {
+	if service.Name == "" {
+		v.addProblem(path+".name", "is required")
+	}
+
+	v.registerBOMRef(path, service.BOMRef)
+
+	for i, child := range service.Services {
+		v.validateService(fmt.Sprintf("%s.services[%d]", path, i), child)
+	}
+}
+
+func (v *validator) registerBOMRef(path, bomRef string) {
+	if bomRef == "" {
+		return
+	}
+
+	if existingPath, ok := v.bomRefs[bomRef]; ok {
+		v.addProblem(path+".bom-ref", "%q is not unique, it is already used by %s", bomRef, existingPath)
+		return
+	}
+
+	v.bomRefs[bomRef] = path
+}
+
+func (v *validator) validateDependency(path string, dependency Dependency) {
+	if dependency.Ref == "" {
+		v.addProblem(path+".ref", "is required")
+	} else if _, ok := v.bomRefs[dependency.Ref]; !ok {
+		v.addProblem(path+".ref", "%q does not reference any component or service", dependency.Ref)
+	}
+
+	for i, ref := range dependency.DependsOn {
+		if _, ok := v.bomRefs[ref]; !ok {
+			v.addProblem(fmt.Sprintf("%s.dependsOn[%d]", path, i), "%q does not reference any component or service", ref)
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,166 @@
+package bom
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, file := range []string{"./testdata/valid.json", "./testdata/valid.xml"} {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			doc, _, err := Decode(data)
+			require.NoError(t, err)
+
+			require.Equal(t, BOMFormat, doc.BOMFormat)
+			require.Equal(t, "1.4", doc.SpecVersion)
+			require.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", doc.SerialNumber)
+			require.Equal(t, 1, doc.Version)
+			require.NotNil(t, doc.Metadata)
+			require.NotNil(t, doc.Metadata.Component)
+			require.Equal(t, "acme-app", doc.Metadata.Component.Name)
+			require.Len(t, doc.Components, 2)
+			require.Equal(t, "library", doc.Components[0].Type)
+			require.Equal(t, "log4j-core", doc.Components[0].Name)
+			require.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", doc.Components[0].PURL)
+			require.Len(t, doc.Dependencies, 3)
+			require.Equal(t, "acme-app", doc.Dependencies[0].Ref)
+			require.Equal(t, []string{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}, doc.Dependencies[0].DependsOn)
+			require.Empty(t, doc.Dependencies[2].DependsOn)
+		})
+	}
+}
+
+func TestDecode_Vulnerabilities(t *testing.T) {
+	for _, file := range []string{"./testdata/vex.json", "./testdata/vex.xml"} {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			doc, _, err := Decode(data)
+			require.NoError(t, err)
+
+			require.Len(t, doc.Components, 1)
+			component := doc.Components[0]
+			require.Equal(t, []Hash{{Algorithm: "SHA-1", Value: "9141212b8507ab50a45525b545b39d224614528b"}}, component.Hashes)
+			require.Equal(t, Licenses{
+				{License: &License{ID: "Apache-2.0"}},
+				{Expression: "Apache-2.0 OR MIT"},
+			}, component.Licenses)
+			require.Equal(t, []Property{{Name: "internal", Value: "false"}}, component.Properties)
+
+			require.Len(t, doc.Vulnerabilities, 1)
+			vulnerability := doc.Vulnerabilities[0]
+			require.Equal(t, "CVE-2021-44228", vulnerability.ID)
+			require.Equal(t, &Source{Name: "NVD", URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}, vulnerability.Source)
+			require.Len(t, vulnerability.Ratings, 1)
+			require.NotNil(t, vulnerability.Ratings[0].Score)
+			require.Equal(t, 10.0, *vulnerability.Ratings[0].Score)
+			require.Equal(t, "critical", vulnerability.Ratings[0].Severity)
+			require.Equal(t, []int{502, 400}, vulnerability.CWEs)
+			require.Equal(t, &VulnerabilityAnalysis{
+				State:     "exploitable",
+				Responses: []string{"update"},
+				Detail:    "Upgrade to 2.17.1",
+			}, vulnerability.Analysis)
+			require.Equal(t, []Affect{{Ref: "log4j-core"}}, vulnerability.Affects)
+
+			require.Empty(t, ValidateDocument(doc, FormatJSON))
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat([]byte("\xEF\xBB\xBF  {}"))
+	require.NoError(t, err)
+	require.Equal(t, FormatJSON, format)
+
+	format, err = DetectFormat([]byte("\n<?xml version=\"1.0\"?><bom/>"))
+	require.NoError(t, err)
+	require.Equal(t, FormatXML, format)
+
+	_, err = DetectFormat([]byte("bomFormat: CycloneDX"))
+	require.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = DetectFormat([]byte(" "))
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		for _, file := range []string{"./testdata/valid.json", "./testdata/valid.xml"} {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			_, err = Validate(data)
+			require.NoError(t, err, file)
+		}
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		format, err := Validate([]byte(`{
+	"bomFormat": "SPDX",
+	"specVersion": "1.1",
+	"serialNumber": "3e671687-395b-41f5-a30f-a58921a69b79",
+	"components": [
+		{"bom-ref": "a", "type": "library", "name": "a"},
+		{"bom-ref": "a", "type": "lib", "name": "b", "components": [{"type": "library"}]}
+	],
+	"services": [{"bom-ref": "svc"}],
+	"dependencies": [
+		{"ref": "a", "dependsOn": ["svc", "c"]},
+		{"ref": "d"},
+		{}
+	]
+}`))
+		require.Equal(t, FormatJSON, format)
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, []Problem{
+			{Path: "bomFormat", Message: `must be "CycloneDX", but is "SPDX"`},
+			{Path: "specVersion", Message: "1.1 is not supported for JSON documents (supported: 1.2, 1.3, 1.4, 1.5, 1.6)"},
+			{Path: "serialNumber", Message: `must be a URN of the form urn:uuid:<uuid>, but is "3e671687-395b-41f5-a30f-a58921a69b79"`},
+			{Path: "components[1].type", Message: `"lib" is not a valid component type`},
+			{Path: "components[1].bom-ref", Message: `"a" is not unique, it is already used by components[0]`},
+			{Path: "components[1].components[0].name", Message: "is required"},
+			{Path: "services[0].name", Message: "is required"},
+			{Path: "dependencies[0].dependsOn[1]", Message: `"c" does not reference any component or service`},
+			{Path: "dependencies[1].ref", Message: `"d" does not reference any component or service`},
+			{Path: "dependencies[2].ref", Message: "is required"},
+		}, validationErr.Problems)
+	})
+
+	t.Run("InvalidXML", func(t *testing.T) {
+		format, err := Validate([]byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.9"><components><component type="library"/></components></bom>`))
+		require.Equal(t, FormatXML, format)
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, []Problem{
+			{Path: "specVersion", Message: "1.9 is not supported for XML documents (supported: 1.0, 1.1, 1.2, 1.3, 1.4, 1.5, 1.6)"},
+			{Path: "components[0].name", Message: "is required"},
+		}, validationErr.Problems)
+	})
+
+	t.Run("NotCycloneDX", func(t *testing.T) {
+		_, err := Validate([]byte(`<bom xmlns="http://spdx.org/rdf/terms"/>`))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Len(t, validationErr.Problems, 2)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := Validate([]byte(`{"bomFormat":`))
+		require.Error(t, err)
+
+		var validationErr *ValidationError
+		require.False(t, errors.As(err, &validationErr))
+	})
+}
@@ -68,3 +68,33 @@ func Example_uploadBOM() {
 		fmt.Printf("failed to wait for bom processing: %v\n", err)
 	}
 }
+
+// This example demonstrates how to upload a Bill of Materials, wait for its processing to complete,
+// and retrieve the resulting findings in one go.
+func Example_uploadBOMAndWait() {
+	client, _ := dtrack.NewClient("https://dtrack.example.com", dtrack.WithAPIKey("..."))
+
+	bomContent, err := os.ReadFile("bom.xml")
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	result, err := client.BOM.UploadAndWait(ctx, dtrack.BOMUploadRequest{
+		ProjectName:    "acme-app",
+		ProjectVersion: "1.0.0",
+		AutoCreate:     true,
+		BOM:            base64.StdEncoding.EncodeToString(bomContent),
+	}, dtrack.WaitOptions{
+		PollInterval:    1 * time.Second,
+		MaxPollInterval: 10 * time.Second,
+		BackoffFactor:   1.5,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("bom processing completed with %d findings (%d critical)\n", len(result.Findings), result.Metrics.Critical)
+}
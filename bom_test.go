@@ -0,0 +1,259 @@
+package dtrack
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nscuro/dtrack-client/bom"
+)
+
+func TestBOMService_UploadAndWait(t *testing.T) {
+	const (
+		token       = "0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9"
+		projectUUID = "2d16089e-6d3a-437e-b334-f27eb2cbd7f4"
+	)
+
+	for _, tc := range []struct {
+		name          string
+		serverVersion string
+		pollPath      string
+	}{
+		{name: "EventToken", serverVersion: "4.11.0", pollPath: "/api/v1/event/token/" + token},
+		{name: "BOMToken", serverVersion: "4.10.0", pollPath: "/api/v1/bom/token/" + token},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewClient("http://localhost", WithServerVersion(tc.serverVersion))
+			require.NoError(t, err)
+
+			httpmock.ActivateNonDefault(client.httpClient)
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+				httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`{"token":"%s"}`, token)))
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost"+tc.pollPath,
+				httpmock.ResponderFromMultipleResponses([]*http.Response{
+					httpmock.NewStringResponse(http.StatusOK, `{"processing":true}`),
+					httpmock.NewStringResponse(http.StatusOK, `{"processing":true}`),
+					httpmock.NewStringResponse(http.StatusOK, `{"processing":false}`),
+				}))
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/lookup?name=acme-app&version=1.0.0",
+				httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`{"uuid":"%s","name":"acme-app","version":"1.0.0"}`, projectUUID)))
+			httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/finding/project/%s", projectUUID),
+				func(req *http.Request) (*http.Response, error) {
+					require.Equal(t, "false", req.URL.Query().Get("suppressed"))
+					res := httpmock.NewStringResponse(http.StatusOK, `[{"vulnerability":{"vulnId":"CVE-2021-44228"}}]`)
+					res.Header.Set("X-Total-Count", "1")
+					return res, nil
+				})
+			httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/metrics/project/%s/current", projectUUID),
+				httpmock.NewStringResponder(http.StatusOK, `{"critical":1,"findingsTotal":1}`))
+
+			statusChan := make(chan BOMProcessingStatus, 10)
+
+			result, err := client.BOM.UploadAndWait(context.TODO(), BOMUploadRequest{
+				ProjectName:    "acme-app",
+				ProjectVersion: "1.0.0",
+				AutoCreate:     true,
+				BOM:            "Ym9t",
+			}, WaitOptions{
+				PollInterval:    time.Millisecond,
+				MaxPollInterval: 4 * time.Millisecond,
+				BackoffFactor:   2,
+				Status:          statusChan,
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, BOMUploadToken(token), result.Token)
+			require.Equal(t, projectUUID, result.Project.UUID.String())
+			require.Len(t, result.Findings, 1)
+			require.Equal(t, "CVE-2021-44228", result.Findings[0].Vulnerability.VulnID)
+			require.Equal(t, 1, result.Metrics.Critical)
+			require.Equal(t, 3, httpmock.GetCallCountInfo()["GET http://localhost"+tc.pollPath])
+
+			close(statusChan) // Owned by the caller, must not have been closed by UploadAndWait
+
+			var statuses []BOMProcessingStatus
+			for status := range statusChan {
+				statuses = append(statuses, status)
+			}
+			require.Len(t, statuses, 3)
+			require.True(t, statuses[0].Processing)
+			require.False(t, statuses[2].Processing)
+			require.Equal(t, 3, statuses[2].Polls)
+		})
+	}
+}
+
+func TestBOMService_UploadAndWait_Timeout(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.10.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/bom/token/foo",
+		httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result, err := client.BOM.UploadAndWait(ctx, BOMUploadRequest{BOM: "Ym9t"}, WaitOptions{PollInterval: 5 * time.Millisecond})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, BOMUploadToken("foo"), result.Token)
+}
+
+func TestBOMService_UploadReader(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.8.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	parentUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+
+	for _, gzipped := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Gzip=%v", gzipped), func(t *testing.T) {
+			httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/bom",
+				func(req *http.Request) (*http.Response, error) {
+					if gzipped {
+						require.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+						gzipReader, err := gzip.NewReader(req.Body)
+						require.NoError(t, err)
+						req.Body = gzipReader
+					} else {
+						require.Empty(t, req.Header.Get("Content-Encoding"))
+					}
+
+					require.NoError(t, req.ParseMultipartForm(1024))
+					require.Equal(t, "acme-app", req.FormValue("projectName"))
+					require.Equal(t, "1.0.0", req.FormValue("projectVersion"))
+					require.Equal(t, "true", req.FormValue("autoCreate"))
+					require.Equal(t, parentUUID.String(), req.FormValue("parentUUID"))
+
+					file, _, err := req.FormFile("bom")
+					require.NoError(t, err)
+					content, err := io.ReadAll(file)
+					require.NoError(t, err)
+					require.Equal(t, `{"bomFormat":"CycloneDX"}`, string(content))
+
+					return httpmock.NewStringResponse(http.StatusOK, `{"token":"foo"}`), nil
+				})
+
+			token, err := client.BOM.UploadReader(context.TODO(), BOMUploadOptions{
+				ProjectName:    "acme-app",
+				ProjectVersion: "1.0.0",
+				AutoCreate:     true,
+				ParentUUID:     &parentUUID,
+				Gzip:           gzipped,
+			}, strings.NewReader(`{"bomFormat":"CycloneDX"}`))
+			require.NoError(t, err)
+			require.Equal(t, BOMUploadToken("foo"), token)
+		})
+	}
+}
+
+func TestBOMService_UploadReader_ReadError(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/bom",
+		func(req *http.Request) (*http.Response, error) {
+			_, err := io.ReadAll(req.Body)
+			return nil, err
+		})
+
+	errRead := errors.New("read failed")
+	_, err = client.BOM.UploadReader(context.TODO(), BOMUploadOptions{ProjectName: "acme-app"}, iotest.ErrReader(errRead))
+	require.ErrorIs(t, err, errRead)
+}
+
+func TestBOMService_Upload_Validate(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	_, err = client.BOM.Upload(context.TODO(), BOMUploadRequest{
+		ProjectName: "acme-app",
+		BOM:         base64.StdEncoding.EncodeToString([]byte(`{"bomFormat":"CycloneDX","specVersion":"1.4","components":[{"type":"library"}]}`)),
+		Validate:    true,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "components[0].name: is required")
+
+	var validationErr *bom.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Equal(t, 0, httpmock.GetTotalCallCount())
+}
+
+func TestBOMService_ExportProjectDocument(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/bom/cyclonedx/project/%s?format=XML&variant=withVulnerabilities", projectUUID),
+		httpmock.NewStringResponder(http.StatusOK, `<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+	<components><component type="library" bom-ref="a"><name>a</name></component></components>
+	<dependencies><dependency ref="a"/></dependencies>
+	<vulnerabilities><vulnerability><id>CVE-2021-44228</id><affects><target><ref>a</ref></target></affects></vulnerability></vulnerabilities>
+</bom>`))
+
+	doc, err := client.BOM.ExportProjectDocument(context.TODO(), projectUUID, BOMFormatXML, BOMVariantWithVulnerabilities)
+	require.NoError(t, err)
+	require.Equal(t, "1.4", doc.SpecVersion)
+	require.Len(t, doc.Components, 1)
+	require.Equal(t, "a", doc.Components[0].Name)
+	require.Len(t, doc.Dependencies, 1)
+	require.Len(t, doc.Vulnerabilities, 1)
+	require.Equal(t, "CVE-2021-44228", doc.Vulnerabilities[0].ID)
+	require.Equal(t, []bom.Affect{{Ref: "a"}}, doc.Vulnerabilities[0].Affects)
+}
+
+func TestBOMService_ExportProjectTo(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+	const exported = `<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1"/>`
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/bom/cyclonedx/project/%s?format=XML", projectUUID),
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "application/vnd.cyclonedx+xml", req.Header.Get("Accept"))
+			res := httpmock.NewStringResponse(http.StatusOK, exported)
+			res.Header.Set("Content-Type", "application/vnd.cyclonedx+xml")
+			return res, nil
+		})
+
+	var buf strings.Builder
+	written, contentType, err := client.BOM.ExportProjectTo(context.TODO(), projectUUID, BOMFormatXML, "", &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(exported)), written)
+	require.Equal(t, "application/vnd.cyclonedx+xml", contentType)
+	require.Equal(t, exported, buf.String())
+}
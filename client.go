@@ -6,9 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
@@ -23,15 +21,26 @@ const (
 type contextKey string
 
 type Client struct {
-	httpClient *http.Client
-	baseURL    *url.URL
-	userAgent  string
-	debug      bool
+	httpClient  *http.Client
+	httpConfig  httpConfig
+	authHeaders []authHeaderTransport
+	baseURL     *url.URL
+	userAgent   string
+	retryPolicy *RetryPolicy
+	logger      Logger
+	logBodies   bool
+	middlewares []Middleware
+
+	serverVersion *serverVersionCache
+
+	rateLimiter  *rateLimiter
+	requestSlots chan struct{}
 
 	About             AboutService
 	Analysis          AnalysisService
 	BOM               BOMService
 	Component         ComponentService
+	Event             EventService
 	Finding           FindingService
 	License           LicenseService
 	Metrics           MetricsService
@@ -58,12 +67,9 @@ func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 	}
 
 	client := Client{
-		baseURL: u,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-		userAgent: DefaultUserAgent,
-		debug:     false,
+		baseURL:       u,
+		userAgent:     DefaultUserAgent,
+		serverVersion: &serverVersionCache{},
 	}
 
 	for _, option := range options {
@@ -72,10 +78,16 @@ func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 		}
 	}
 
+	client.httpClient, err = client.buildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	client.About = AboutService{client: &client}
 	client.Analysis = AnalysisService{client: &client}
 	client.BOM = BOMService{client: &client}
 	client.Component = ComponentService{client: &client}
+	client.Event = EventService{client: &client}
 	client.Finding = FindingService{client: &client}
 	client.License = LicenseService{client: &client}
 	client.Metrics = MetricsService{client: &client}
@@ -113,6 +125,10 @@ func (c Client) newRequest(ctx context.Context, method, path string, options ...
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
 
+	if err = withOperation(callerOperation(1))(req); err != nil {
+		return nil, err
+	}
+
 	for _, option := range options {
 		if err = option(req); err != nil {
 			return nil, err
@@ -150,25 +166,30 @@ func withBody(body interface{}) requestOption {
 
 		var (
 			contentType string
-			bodyBuf     io.ReadWriter
+			bodyBuf     = new(bytes.Buffer)
 		)
 
 		switch body := body.(type) {
 		case url.Values:
-			bodyBuf = bytes.NewBufferString("")
 			if _, err := fmt.Fprint(bodyBuf, body.Encode()); err != nil {
 				return err
 			}
 			contentType = "application/x-www-form-urlencoded"
 		default:
-			bodyBuf = new(bytes.Buffer)
 			if err := json.NewEncoder(bodyBuf).Encode(body); err != nil {
 				return err
 			}
 			contentType = "application/json"
 		}
 
-		req.Body = io.NopCloser(bodyBuf)
+		// Keep the encoded body around, so it can be re-sent when retrying.
+		bodyBytes := bodyBuf.Bytes()
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+		}
+
+		req.Body, _ = req.GetBody()
+		req.ContentLength = int64(len(bodyBytes))
 		req.Header.Set("Content-Type", contentType)
 
 		return nil
@@ -206,23 +227,84 @@ func withPageOptions(po PageOptions) requestOption {
 	}
 }
 
-func (c Client) doRequest(req *http.Request, v interface{}) (a apiResponse, err error) {
-	if c.debug {
-		reqDump, _ := httputil.DumpRequestOut(req, true)
-		log.Printf("sending request:\n>>>>>>\n%s\n>>>>>>\n", string(reqDump))
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+type SortOptions struct {
+	Field string    // Name of the field to sort by
+	Order SortOrder // Order to sort in; defaults to ascending
+}
+
+func withSortOptions(so SortOptions) requestOption {
+	return func(req *http.Request) error {
+		if so.Field == "" {
+			return nil
+		}
+
+		query := req.URL.Query()
+
+		query.Set("sortName", so.Field)
+		if so.Order != "" {
+			query.Set("sortOrder", string(so.Order))
+		}
+
+		req.URL.RawQuery = query.Encode()
+
+		return nil
+	}
+}
+
+func (c Client) doRequest(req *http.Request, v interface{}) (a APIResponse, err error) {
+	handler := func(_ string, req *http.Request) (APIResponse, error) {
+		return c.executeRequest(req, v)
 	}
 
-	res, err := c.httpClient.Do(req)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+
+	return handler(operationFromContext(req.Context()), req)
+}
+
+// responseWriter may be passed to doRequest in order to stream
+// the response body to w, instead of decoding it.
+type responseWriter struct {
+	w           io.Writer
+	written     int64
+	contentType string
+}
+
+// executeRequest sends req and decodes the response into v.
+func (c Client) executeRequest(req *http.Request, v interface{}) (a APIResponse, err error) {
+	releaseSlot, err := c.acquireRequestSlot(req.Context())
 	if err != nil {
 		return
 	}
-	defer res.Body.Close()
+	defer releaseSlot()
+
+	start := time.Now()
+	res, err := c.sendRequest(req)
+	latency := time.Since(start)
 
-	if c.debug {
-		resDump, _ := httputil.DumpResponse(res, true)
-		log.Printf("received response:\n<<<<<<\n%s\n<<<<<<\n", string(resDump))
+	if c.logger != nil {
+		var resLogAttrs []any
+		if res != nil {
+			resLogAttrs = c.responseLogAttrs(req, res)
+		}
+		defer func() {
+			c.logRequest(req, resLogAttrs, a.TotalCount, latency, err)
+		}()
 	}
 
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
 	err = checkResponseForError(res)
 	if err != nil {
 		return
@@ -230,6 +312,12 @@ func (c Client) doRequest(req *http.Request, v interface{}) (a apiResponse, err
 
 	if v != nil {
 		switch vt := v.(type) {
+		case *responseWriter:
+			vt.contentType = res.Header.Get("Content-Type")
+			vt.written, err = io.Copy(vt.w, res.Body)
+			if err != nil {
+				return
+			}
 		case *string:
 			if content, readErr := io.ReadAll(res.Body); readErr == nil {
 				*vt = strings.TrimSpace(string(content))
@@ -249,13 +337,55 @@ func (c Client) doRequest(req *http.Request, v interface{}) (a apiResponse, err
 	return
 }
 
-type apiResponse struct {
+// sendRequest sends req, retrying it according to the client's retry policy.
+func (c Client) sendRequest(req *http.Request) (res *http.Response, err error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err = rewindBody(req); err != nil {
+				return
+			}
+		}
+
+		if c.rateLimiter != nil {
+			if err = c.rateLimiter.wait(req.Context()); err != nil {
+				return
+			}
+		}
+
+		res, err = c.httpClient.Do(req)
+		if c.retryPolicy == nil || !c.retryPolicy.shouldRetry(req, res, err, attempt) {
+			return
+		}
+
+		backoff, ok := c.retryPolicy.backoff(res, attempt)
+		if !ok || !fitsDeadline(req.Context(), backoff) {
+			return
+		}
+
+		if c.logger != nil {
+			c.logRetry(req, res, err, attempt, backoff)
+		}
+
+		if res != nil {
+			drainBody(res.Body)
+			res = nil
+		}
+
+		if err = sleepContext(req.Context(), backoff); err != nil {
+			return
+		}
+	}
+}
+
+// APIResponse is a response received from the Dependency-Track API.
+// Its body has already been consumed and closed.
+type APIResponse struct {
 	*http.Response
-	TotalCount int
+	TotalCount int // Total number of items, as reported by paginated resources
 }
 
-func (c Client) newAPIResponse(res *http.Response) (a apiResponse, err error) {
-	a = apiResponse{Response: res}
+func (c Client) newAPIResponse(res *http.Response) (a APIResponse, err error) {
+	a = APIResponse{Response: res}
 
 	totalCount, ok := a.Header["X-Total-Count"]
 	if ok && len(totalCount) > 0 {
@@ -273,11 +403,18 @@ func (c Client) newAPIResponse(res *http.Response) (a apiResponse, err error) {
 type ClientOption func(*Client) error
 
 // WithDebug toggles the debug mode.
-// When enabled, HTTP requests and responses will be logged to stderr.
-// DO NOT USE IN PRODUCTION, authorization headers are not cleared!
+// When enabled, HTTP requests and responses, including their headers and bodies,
+// will be logged to stderr using the standard logger of the log package.
+//
+// Deprecated: Use WithLogger and WithBodyLogging instead.
 func WithDebug(debug bool) ClientOption {
 	return func(c *Client) error {
-		c.debug = debug
+		if debug {
+			c.logger = stdLogger{}
+		} else if _, ok := c.logger.(stdLogger); ok {
+			c.logger = nil
+		}
+		c.logBodies = debug
 		return nil
 	}
 }
@@ -293,7 +430,7 @@ func WithUserAgent(userAgent string) ClientOption {
 // WithTimeout overrides the default timeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) error {
-		c.httpClient.Timeout = timeout
+		c.httpConfig.timeout = &timeout
 		return nil
 	}
 }
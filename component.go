@@ -1,9 +1,12 @@
 package dtrack
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/google/uuid"
 )
@@ -40,6 +43,47 @@ type Component struct {
 	ResolvedLicense    *License  `json:"resolvedLicense,omitempty"`
 	DirectDependencies string    `json:"directDependencies,omitempty"`
 	Notes              string    `json:"notes,omitempty"`
+	Project            *Project  `json:"project,omitempty"`
+}
+
+func (c *Component) UnmarshalJSON(data []byte) error {
+	// Prevent infinite recursion by using a type without the UnmarshalJSON method.
+	type component Component
+
+	// In findings, the project of a component is provided as UUID,
+	// with its name and version in separate fields.
+	var fc struct {
+		component
+		Project        json.RawMessage `json:"project"`
+		ProjectName    string          `json:"projectName"`
+		ProjectVersion string          `json:"projectVersion"`
+	}
+
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return err
+	}
+
+	*c = Component(fc.component)
+
+	switch {
+	case len(fc.Project) == 0 || bytes.Equal(fc.Project, []byte("null")):
+	case fc.Project[0] == '"':
+		var projectUUID uuid.UUID
+		if err := json.Unmarshal(fc.Project, &projectUUID); err != nil {
+			return err
+		}
+		c.Project = &Project{
+			UUID:    projectUUID,
+			Name:    fc.ProjectName,
+			Version: fc.ProjectVersion,
+		}
+	default:
+		if err := json.Unmarshal(fc.Project, &c.Project); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 type ComponentService struct {
@@ -71,6 +115,7 @@ func (cs ComponentService) GetAll(ctx context.Context, projectUUID uuid.UUID, po
 	return
 }
 
+// Deprecated: Use CreateForProject instead.
 func (cs ComponentService) Create(ctx context.Context, projectUUID string, component Component) (c Component, err error) {
 	req, err := cs.client.newRequest(ctx, http.MethodPut,
 		fmt.Sprintf("/api/v1/component/project/%s", projectUUID),
@@ -82,3 +127,121 @@ func (cs ComponentService) Create(ctx context.Context, projectUUID string, compo
 	_, err = cs.client.doRequest(req, &c)
 	return
 }
+
+// CreateForProject creates a component in the project identified by projectUUID.
+func (cs ComponentService) CreateForProject(ctx context.Context, projectUUID uuid.UUID, component Component) (c Component, err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/component/project/%s", projectUUID), withBody(component))
+	if err != nil {
+		return
+	}
+
+	_, err = cs.client.doRequest(req, &c)
+	return
+}
+
+// Update updates the component identified by component.UUID.
+func (cs ComponentService) Update(ctx context.Context, component Component) (c Component, err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodPost, "/api/v1/component", withBody(component))
+	if err != nil {
+		return
+	}
+
+	_, err = cs.client.doRequest(req, &c)
+	return
+}
+
+func (cs ComponentService) Delete(ctx context.Context, componentUUID uuid.UUID) (err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/component/%s", componentUUID))
+	if err != nil {
+		return
+	}
+
+	_, err = cs.client.doRequest(req, nil)
+	return
+}
+
+// GetByHash fetches all components with the given hash, across all projects.
+// The hash algorithm is inferred by the server from the length of the hash.
+func (cs ComponentService) GetByHash(ctx context.Context, hash string, po PageOptions) (p Page[Component], err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/component/hash/%s", url.PathEscape(hash)), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// ComponentIdentityQuery describes the identity of components to search for.
+// Only non-empty fields are considered. The server performs partial matches on
+// Group and Name, but exact matches on all other fields.
+type ComponentIdentityQuery struct {
+	Group       string
+	Name        string
+	Version     string
+	PURL        string
+	CPE         string
+	SWIDTagID   string
+	ProjectUUID *uuid.UUID // Limits the search to a single project
+}
+
+func (q ComponentIdentityQuery) params() map[string]string {
+	params := make(map[string]string)
+	if q.Group != "" {
+		params["group"] = q.Group
+	}
+	if q.Name != "" {
+		params["name"] = q.Name
+	}
+	if q.Version != "" {
+		params["version"] = q.Version
+	}
+	if q.PURL != "" {
+		params["purl"] = q.PURL
+	}
+	if q.CPE != "" {
+		params["cpe"] = q.CPE
+	}
+	if q.SWIDTagID != "" {
+		params["swidTagId"] = q.SWIDTagID
+	}
+	if q.ProjectUUID != nil {
+		params["project"] = q.ProjectUUID.String()
+	}
+
+	return params
+}
+
+// GetByIdentity fetches all components matching the given identity, across all projects.
+// The project of each component is included in the response.
+func (cs ComponentService) GetByIdentity(ctx context.Context, query ComponentIdentityQuery, po PageOptions) (p Page[Component], err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodGet, "/api/v1/component/identity", withParams(query.params()), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// IdentifyInternal triggers the identification of internal components across the entire portfolio.
+// Identification is performed asynchronously by the server.
+func (cs ComponentService) IdentifyInternal(ctx context.Context) (err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodGet, "/api/v1/component/internal/identify")
+	if err != nil {
+		return
+	}
+
+	_, err = cs.client.doRequest(req, nil)
+	return
+}
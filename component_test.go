@@ -0,0 +1,129 @@
+package dtrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentService_GetByIdentity(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/component/identity",
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "org.apache.logging.log4j", req.URL.Query().Get("group"))
+			require.Equal(t, "log4j-core", req.URL.Query().Get("name"))
+			require.Equal(t, "2.14.1", req.URL.Query().Get("version"))
+			require.False(t, req.URL.Query().Has("purl"))
+			require.False(t, req.URL.Query().Has("project"))
+
+			res := httpmock.NewStringResponse(http.StatusOK, `[{
+	"uuid": "0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9",
+	"name": "log4j-core",
+	"version": "2.14.1",
+	"project": {"uuid": "2d16089e-6d3a-437e-b334-f27eb2cbd7f4", "name": "acme-app"}
+}]`)
+			res.Header.Set("X-Total-Count", "1")
+			return res, nil
+		})
+
+	page, err := client.Component.GetByIdentity(context.TODO(), ComponentIdentityQuery{
+		Group:   "org.apache.logging.log4j",
+		Name:    "log4j-core",
+		Version: "2.14.1",
+	}, PageOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.TotalCount)
+	require.Len(t, page.Items, 1)
+	require.NotNil(t, page.Items[0].Project)
+	require.Equal(t, "acme-app", page.Items[0].Project.Name)
+}
+
+func TestComponentService_Update(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	componentUUID := uuid.MustParse("0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9")
+
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/component",
+		func(req *http.Request) (*http.Response, error) {
+			var component Component
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&component))
+			require.Equal(t, componentUUID, component.UUID)
+			return httpmock.NewJsonResponse(http.StatusOK, component)
+		})
+
+	component, err := client.Component.Update(context.TODO(), Component{UUID: componentUUID, Name: "log4j-core", Version: "2.17.1"})
+	require.NoError(t, err)
+	require.Equal(t, "2.17.1", component.Version)
+}
+
+func TestComponentService_FindUsages(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/component/identity",
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "org.apache.logging.log4j", req.URL.Query().Get("group"))
+			require.Equal(t, "log4j-core", req.URL.Query().Get("name"))
+			require.Empty(t, req.URL.Query().Get("version"))
+
+			var body string
+			switch req.URL.Query().Get("pageNumber") {
+			case "1":
+				body = `[
+	{"name":"log4j-core","version":"2.14.1","purl":"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar","project":{"uuid":"2d16089e-6d3a-437e-b334-f27eb2cbd7f4","name":"b-app"}},
+	{"name":"log4j-core-extras","version":"2.14.1","purl":"pkg:maven/org.apache.logging.log4j/log4j-core-extras@2.14.1","project":{"uuid":"2d16089e-6d3a-437e-b334-f27eb2cbd7f4","name":"b-app"}}
+]`
+			case "2":
+				body = `[
+	{"name":"log4j-core","version":"2.17.1","purl":"pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1","project":{"uuid":"c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0","name":"a-app"}},
+	{"group":"org.apache.logging.log4j","name":"log4j-core","version":"2.0","project":{"uuid":"c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0","name":"a-app"}}
+]`
+			default:
+				body = `[]`
+			}
+
+			res := httpmock.NewStringResponse(http.StatusOK, body)
+			res.Header.Set("X-Total-Count", "4")
+			return res, nil
+		})
+
+	var progress []ComponentUsageProgress
+
+	usages, err := client.Component.FindUsages(context.TODO(), ComponentQuery{
+		PURL:               "pkg:maven/org.apache.logging.log4j/log4j-core",
+		VersionRange:       ">=2.0, <2.17.1",
+		ConcurrencyOptions: ConcurrencyOptions{PageSize: 2},
+		Progress: func(p ComponentUsageProgress) {
+			progress = append(progress, p)
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, usages, 2)
+	require.Equal(t, "a-app", usages[0].Project.Name)
+	require.Len(t, usages[0].Components, 1)
+	require.Equal(t, "2.0", usages[0].Components[0].Version)
+	require.Equal(t, "b-app", usages[1].Project.Name)
+	require.Len(t, usages[1].Components, 1)
+	require.Equal(t, "2.14.1", usages[1].Components[0].Version)
+
+	require.Len(t, progress, 2)
+	require.Equal(t, ComponentUsageProgress{PagesFetched: 2, TotalPages: 2, ComponentsScanned: 4, TotalComponents: 4, Matches: 2}, progress[1])
+}
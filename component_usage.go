@@ -0,0 +1,192 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ComponentQuery describes components to search for across the portfolio.
+//
+// Either PURL, or Name (and optionally Group) must be provided.
+type ComponentQuery struct {
+	// PURL is the Package URL of the component, e.g. pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1.
+	// If it contains a version, only that exact version matches, unless VersionRange is set.
+	// Qualifiers and subpath are ignored.
+	PURL string
+
+	Group string
+	Name  string
+
+	// VersionRange restricts the versions of matching components. See VersionRange for the syntax.
+	VersionRange string
+
+	// ConcurrencyOptions controls how pages of search results are fetched.
+	ConcurrencyOptions
+
+	// Progress, if not nil, is called after each page of search results has been processed.
+	// It is never called concurrently.
+	Progress func(progress ComponentUsageProgress)
+}
+
+// ComponentUsageProgress reports the progress of ComponentService.FindUsages.
+type ComponentUsageProgress struct {
+	PagesFetched      int
+	TotalPages        int
+	ComponentsScanned int
+	TotalComponents   int
+	Matches           int
+}
+
+// ComponentUsage lists the components of a project that match a ComponentQuery.
+type ComponentUsage struct {
+	Project    Project
+	Components []Component
+}
+
+// FindUsages finds all components matching query across the entire portfolio,
+// and groups them by project. Usages are ordered by project name and version.
+//
+// Candidates are searched for using the component identity endpoint by group and name,
+// rather than by Package URL, because the server only matches Package URLs exactly,
+// including their qualifiers. Candidates are then matched against query locally.
+func (cs ComponentService) FindUsages(ctx context.Context, query ComponentQuery) (usages []ComponentUsage, err error) {
+	matcher, err := newComponentMatcher(query)
+	if err != nil {
+		return
+	}
+
+	identity := ComponentIdentityQuery{
+		Group: matcher.group,
+		Name:  matcher.name,
+	}
+	if matcher.versionRange == nil {
+		identity.Version = matcher.version
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	var (
+		progress     ComponentUsageProgress
+		usagesByUUID = make(map[uuid.UUID]*ComponentUsage)
+	)
+
+	err = forEachPageConcurrent(ctx, func(ctx context.Context, po PageOptions) (Page[Component], error) {
+		return cs.GetByIdentity(ctx, identity, po)
+	}, query.ConcurrencyOptions, func(_ int, page Page[Component]) error {
+		for _, component := range page.Items {
+			if !matcher.matches(component) {
+				continue
+			}
+
+			var project Project
+			if component.Project != nil {
+				project = *component.Project
+			}
+
+			usage, ok := usagesByUUID[project.UUID]
+			if !ok {
+				usage = &ComponentUsage{Project: project}
+				usagesByUUID[project.UUID] = usage
+			}
+			usage.Components = append(usage.Components, component)
+			progress.Matches++
+		}
+
+		if query.Progress != nil {
+			progress.PagesFetched++
+			progress.TotalPages = (page.TotalCount + pageSize - 1) / pageSize
+			progress.ComponentsScanned += len(page.Items)
+			progress.TotalComponents = page.TotalCount
+			query.Progress(progress)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	usages = make([]ComponentUsage, 0, len(usagesByUUID))
+	for _, usage := range usagesByUUID {
+		sort.SliceStable(usage.Components, func(i, j int) bool {
+			return compareVersionStrings(usage.Components[i].Version, usage.Components[j].Version) < 0
+		})
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Project.Name != usages[j].Project.Name {
+			return usages[i].Project.Name < usages[j].Project.Name
+		}
+		return compareVersionStrings(usages[i].Project.Version, usages[j].Project.Version) < 0
+	})
+
+	return
+}
+
+// componentMatcher matches components against a ComponentQuery.
+type componentMatcher struct {
+	purl         *PackageURL
+	group        string
+	name         string
+	version      string
+	versionRange *VersionRange
+}
+
+func newComponentMatcher(query ComponentQuery) (m componentMatcher, err error) {
+	m.group, m.name = query.Group, query.Name
+
+	if query.PURL != "" {
+		var purl PackageURL
+		purl, err = ParsePackageURL(query.PURL)
+		if err != nil {
+			return
+		}
+
+		m.purl = &purl
+		m.group, m.name, m.version = purl.Namespace, purl.Name, purl.Version
+	}
+
+	if m.name == "" {
+		err = fmt.Errorf("component query requires either a package url or a name")
+		return
+	}
+
+	if query.VersionRange != "" {
+		var versionRange VersionRange
+		versionRange, err = ParseVersionRange(query.VersionRange)
+		if err != nil {
+			return
+		}
+		m.versionRange = &versionRange
+	}
+
+	return
+}
+
+func (m componentMatcher) matches(component Component) bool {
+	// The identity endpoint performs partial matches on group and name.
+	if m.purl != nil && component.PURL != "" {
+		purl, err := ParsePackageURL(component.PURL)
+		if err != nil || !m.purl.Matches(purl) {
+			return false
+		}
+	} else if !strings.EqualFold(component.Name, m.name) || (m.group != "" && !strings.EqualFold(component.Group, m.group)) {
+		return false
+	}
+
+	switch {
+	case m.versionRange != nil:
+		return m.versionRange.Contains(component.Version)
+	case m.version != "":
+		return component.Version == m.version
+	default:
+		return true
+	}
+}
@@ -0,0 +1,342 @@
+package dtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// DependencyRef references a direct dependency of a project or component.
+type DependencyRef struct {
+	UUID    uuid.UUID `json:"uuid"`
+	Name    string    `json:"name,omitempty"`
+	Version string    `json:"version,omitempty"`
+	PURL    string    `json:"purl,omitempty"`
+}
+
+// ParseDirectDependencies parses the JSON encoded direct dependencies
+// of a project or component, as found in their DirectDependencies field.
+func ParseDirectDependencies(directDependencies string) (refs []DependencyRef, err error) {
+	if directDependencies == "" {
+		return
+	}
+
+	err = json.Unmarshal([]byte(directDependencies), &refs)
+	if err != nil {
+		err = fmt.Errorf("failed to parse direct dependencies: %w", err)
+	}
+
+	return
+}
+
+// DirectDependencyRefs parses the project's direct dependencies.
+func (p Project) DirectDependencyRefs() ([]DependencyRef, error) {
+	return ParseDirectDependencies(p.DirectDependencies)
+}
+
+// DirectDependencyRefs parses the component's direct dependencies.
+func (c Component) DirectDependencyRefs() ([]DependencyRef, error) {
+	return ParseDirectDependencies(c.DirectDependencies)
+}
+
+// minVersionDependencyGraph is the minimum version of Dependency-Track that
+// provides the dependency graph endpoints.
+var minVersionDependencyGraph = Version{Major: 4, Minor: 9}
+
+// DirectDependencies fetches the direct dependencies of a project.
+func (ps ProjectService) DirectDependencies(ctx context.Context, projectUUID uuid.UUID) (cs []Component, err error) {
+	err = ps.client.requireServerVersion(ctx, minVersionDependencyGraph)
+	if err != nil {
+		return
+	}
+
+	req, err := ps.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/dependencyGraph/project/%s/directDependencies", projectUUID))
+	if err != nil {
+		return
+	}
+
+	_, err = ps.client.doRequest(req, &cs)
+	return
+}
+
+// DirectDependencies fetches the direct dependencies of a component.
+func (cs ComponentService) DirectDependencies(ctx context.Context, componentUUID uuid.UUID) (deps []Component, err error) {
+	err = cs.client.requireServerVersion(ctx, minVersionDependencyGraph)
+	if err != nil {
+		return
+	}
+
+	req, err := cs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/dependencyGraph/component/%s/directDependencies", componentUUID))
+	if err != nil {
+		return
+	}
+
+	_, err = cs.client.doRequest(req, &deps)
+	return
+}
+
+// DependencyGraph builds the dependency graph of a project.
+// The graph's root node represents the project itself.
+func (ps ProjectService) DependencyGraph(ctx context.Context, projectUUID uuid.UUID) (g *DependencyGraph, err error) {
+	project, err := ps.Get(ctx, projectUUID)
+	if err != nil {
+		return
+	}
+
+	g = NewDependencyGraph(Component{
+		UUID:    project.UUID,
+		Name:    project.Name,
+		Version: project.Version,
+		PURL:    project.PURL,
+	})
+	g.Project = &project
+
+	err = g.expand(ctx, func(ctx context.Context, nodeUUID uuid.UUID) ([]Component, error) {
+		if nodeUUID == projectUUID {
+			return ps.DirectDependencies(ctx, projectUUID)
+		}
+		return ps.client.Component.DirectDependencies(ctx, nodeUUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// DependencyGraph builds the dependency graph of a component,
+// i.e. the graph of its transitive dependencies.
+func (cs ComponentService) DependencyGraph(ctx context.Context, componentUUID uuid.UUID) (g *DependencyGraph, err error) {
+	component, err := cs.Get(ctx, componentUUID)
+	if err != nil {
+		return
+	}
+
+	g = NewDependencyGraph(component)
+
+	err = g.expand(ctx, func(ctx context.Context, nodeUUID uuid.UUID) ([]Component, error) {
+		return cs.DirectDependencies(ctx, nodeUUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// expand adds the transitive dependencies of the graph's root, breadth-first.
+func (g *DependencyGraph) expand(ctx context.Context, directDependenciesFunc func(ctx context.Context, nodeUUID uuid.UUID) ([]Component, error)) error {
+	visited := map[uuid.UUID]bool{g.Root: true}
+
+	queue := []uuid.UUID{g.Root}
+	for len(queue) > 0 {
+		nodeUUID := queue[0]
+		queue = queue[1:]
+
+		dependencies, err := directDependenciesFunc(ctx, nodeUUID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch direct dependencies of %s: %w", nodeUUID, err)
+		}
+
+		for _, dependency := range dependencies {
+			g.AddNode(dependency)
+			g.AddEdge(nodeUUID, dependency.UUID)
+
+			if !visited[dependency.UUID] {
+				visited[dependency.UUID] = true
+				queue = append(queue, dependency.UUID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DependencyGraph is a directed graph of components, in which edges point from a
+// component to its direct dependencies. It may contain cycles.
+type DependencyGraph struct {
+	Root    uuid.UUID
+	Project *Project // The project whose graph this is, nil for graphs of components
+	Nodes   map[uuid.UUID]Component
+
+	edges map[uuid.UUID][]uuid.UUID
+}
+
+// NewDependencyGraph creates a graph containing only root.
+func NewDependencyGraph(root Component) *DependencyGraph {
+	g := &DependencyGraph{
+		Root:  root.UUID,
+		Nodes: make(map[uuid.UUID]Component),
+		edges: make(map[uuid.UUID][]uuid.UUID),
+	}
+	g.AddNode(root)
+
+	return g
+}
+
+// AddNode adds a node to the graph. Existing nodes with the same UUID are replaced.
+func (g *DependencyGraph) AddNode(component Component) {
+	g.Nodes[component.UUID] = component
+}
+
+// AddEdge adds a dependency of from on to. Duplicate edges are ignored.
+func (g *DependencyGraph) AddEdge(from, to uuid.UUID) {
+	for _, existing := range g.edges[from] {
+		if existing == to {
+			return
+		}
+	}
+
+	g.edges[from] = append(g.edges[from], to)
+}
+
+// DirectDependencies returns the direct dependencies of a node.
+func (g *DependencyGraph) DirectDependencies(nodeUUID uuid.UUID) []uuid.UUID {
+	return append([]uuid.UUID(nil), g.edges[nodeUUID]...)
+}
+
+// TransitiveDependencies returns all nodes reachable from a node, in breadth-first order.
+// The node itself is only included if it is part of a cycle.
+func (g *DependencyGraph) TransitiveDependencies(nodeUUID uuid.UUID) (deps []uuid.UUID) {
+	visited := make(map[uuid.UUID]bool)
+
+	queue := append([]uuid.UUID(nil), g.edges[nodeUUID]...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		deps = append(deps, current)
+
+		queue = append(queue, g.edges[current]...)
+	}
+
+	return
+}
+
+// Dependents returns all nodes that directly depend on a node.
+func (g *DependencyGraph) Dependents(nodeUUID uuid.UUID) (dependents []uuid.UUID) {
+	for _, from := range g.sortedNodeUUIDs() {
+		for _, to := range g.edges[from] {
+			if to == nodeUUID {
+				dependents = append(dependents, from)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// PathTo returns the shortest path from the root to a node, including both.
+// It returns nil if the node is not reachable from the root.
+func (g *DependencyGraph) PathTo(nodeUUID uuid.UUID) []uuid.UUID {
+	if nodeUUID == g.Root {
+		return []uuid.UUID{g.Root}
+	}
+
+	predecessors := map[uuid.UUID]uuid.UUID{g.Root: uuid.Nil}
+
+	queue := []uuid.UUID{g.Root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g.edges[current] {
+			if _, seen := predecessors[next]; seen {
+				continue
+			}
+			predecessors[next] = current
+
+			if next == nodeUUID {
+				var path []uuid.UUID
+				for n := next; n != uuid.Nil; n = predecessors[n] {
+					path = append([]uuid.UUID{n}, path...)
+				}
+				return path
+			}
+
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// Cycles returns the cycles in the graph. Each cycle is reported once, as the path
+// of nodes that leads back to its first node. Cycles not reachable from the root are
+// reported as well.
+func (g *DependencyGraph) Cycles() (cycles [][]uuid.UUID) {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	var (
+		state = make(map[uuid.UUID]int)
+		stack []uuid.UUID
+		visit func(nodeUUID uuid.UUID)
+	)
+
+	visit = func(nodeUUID uuid.UUID) {
+		state[nodeUUID] = inProgress
+		stack = append(stack, nodeUUID)
+
+		for _, next := range g.edges[nodeUUID] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case inProgress:
+				// Back edge: the cycle is the part of the stack starting at next.
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == next {
+						cycles = append(cycles, append([]uuid.UUID(nil), stack[i:]...))
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[nodeUUID] = done
+	}
+
+	visit(g.Root)
+	for _, nodeUUID := range g.sortedNodeUUIDs() {
+		if state[nodeUUID] == unvisited {
+			visit(nodeUUID)
+		}
+	}
+
+	return
+}
+
+// sortedNodeUUIDs returns the UUIDs of all nodes with outgoing edges or
+// component details, in a stable order.
+func (g *DependencyGraph) sortedNodeUUIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(g.Nodes))
+	nodeUUIDs := make([]uuid.UUID, 0, len(g.Nodes))
+	for nodeUUID := range g.Nodes {
+		seen[nodeUUID] = true
+		nodeUUIDs = append(nodeUUIDs, nodeUUID)
+	}
+	for nodeUUID := range g.edges {
+		if !seen[nodeUUID] {
+			nodeUUIDs = append(nodeUUIDs, nodeUUID)
+		}
+	}
+
+	sort.Slice(nodeUUIDs, func(i, j int) bool {
+		return nodeUUIDs[i].String() < nodeUUIDs[j].String()
+	})
+
+	return nodeUUIDs
+}
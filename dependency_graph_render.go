@@ -0,0 +1,283 @@
+package dtrack
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// GraphRenderOptions customizes the rendering of a DependencyGraph.
+type GraphRenderOptions struct {
+	// Findings are used to color components by the highest severity of their vulnerabilities.
+	Findings []Finding
+
+	// OnlyVulnerablePaths limits the rendered graph to the paths from the root
+	// to components with findings.
+	OnlyVulnerablePaths bool
+}
+
+// severityColors are the fill colors of nodes, by severity.
+var severityColors = map[string]string{
+	"CRITICAL":   "#d32f2f",
+	"HIGH":       "#f57c00",
+	"MEDIUM":     "#fbc02d",
+	"LOW":        "#1976d2",
+	"INFO":       "#90a4ae",
+	"UNASSIGNED": "#bdbdbd",
+}
+
+// severityRanks orders severities, higher is more severe.
+var severityRanks = map[string]int{
+	"UNASSIGNED": 1,
+	"INFO":       2,
+	"LOW":        3,
+	"MEDIUM":     4,
+	"HIGH":       5,
+	"CRITICAL":   6,
+}
+
+// highestSeverities determines the highest severity of the findings of each component.
+func highestSeverities(findings []Finding) map[uuid.UUID]string {
+	severities := make(map[uuid.UUID]string)
+	for _, finding := range findings {
+		severity := strings.ToUpper(finding.Vulnerability.Severity)
+		if severity == "" {
+			severity = "UNASSIGNED"
+		}
+
+		current, ok := severities[finding.Component.UUID]
+		if !ok || severityRanks[severity] > severityRanks[current] {
+			severities[finding.Component.UUID] = severity
+		}
+	}
+
+	return severities
+}
+
+// Subgraph returns the graph containing only the nodes and edges on paths from the root to any of targets.
+func (g *DependencyGraph) Subgraph(targets ...uuid.UUID) *DependencyGraph {
+	reachable := make(map[uuid.UUID]bool)
+	reachable[g.Root] = true
+	for _, nodeUUID := range g.TransitiveDependencies(g.Root) {
+		reachable[nodeUUID] = true
+	}
+
+	reverseEdges := make(map[uuid.UUID][]uuid.UUID)
+	for from, tos := range g.edges {
+		for _, to := range tos {
+			reverseEdges[to] = append(reverseEdges[to], from)
+		}
+	}
+
+	// Nodes that are reachable from the root, and from which a target is reachable.
+	onPath := make(map[uuid.UUID]bool)
+	var queue []uuid.UUID
+	for _, target := range targets {
+		if reachable[target] {
+			queue = append(queue, target)
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if onPath[current] {
+			continue
+		}
+		onPath[current] = true
+
+		for _, from := range reverseEdges[current] {
+			if reachable[from] {
+				queue = append(queue, from)
+			}
+		}
+	}
+
+	sub := &DependencyGraph{
+		Root:    g.Root,
+		Project: g.Project,
+		Nodes:   make(map[uuid.UUID]Component),
+		edges:   make(map[uuid.UUID][]uuid.UUID),
+	}
+	onPath[g.Root] = true
+	for nodeUUID := range onPath {
+		if component, ok := g.Nodes[nodeUUID]; ok {
+			sub.AddNode(component)
+		}
+	}
+	for from, tos := range g.edges {
+		if !onPath[from] {
+			continue
+		}
+		for _, to := range tos {
+			if onPath[to] {
+				sub.AddEdge(from, to)
+			}
+		}
+	}
+
+	return sub
+}
+
+// WriteDOT renders the graph in the Graphviz DOT language.
+func (g *DependencyGraph) WriteDOT(w io.Writer, opts GraphRenderOptions) error {
+	g, nodeUUIDs, severities := g.prepareRendering(opts)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(g.label(g.Root, " ")))
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];\n")
+
+	for _, nodeUUID := range nodeUUIDs {
+		fmt.Fprintf(&sb, "  %s [label=%s", dotQuote(nodeUUID.String()), dotQuote(g.label(nodeUUID, "\n")))
+		if severity, ok := severities[nodeUUID]; ok {
+			fmt.Fprintf(&sb, ", fillcolor=%s, tooltip=%s", dotQuote(severityColors[severity]), dotQuote(severity))
+		}
+		if nodeUUID == g.Root {
+			sb.WriteString(", penwidth=2")
+		}
+		sb.WriteString("];\n")
+	}
+
+	for _, from := range nodeUUIDs {
+		for _, to := range g.edges[from] {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", dotQuote(from.String()), dotQuote(to.String()))
+		}
+	}
+
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart.
+func (g *DependencyGraph) WriteMermaid(w io.Writer, opts GraphRenderOptions) error {
+	g, nodeUUIDs, severities := g.prepareRendering(opts)
+
+	ids := make(map[uuid.UUID]string, len(nodeUUIDs))
+	for i, nodeUUID := range nodeUUIDs {
+		ids[nodeUUID] = fmt.Sprintf("n%d", i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	for _, nodeUUID := range nodeUUIDs {
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", ids[nodeUUID], g.mermaidLabel(nodeUUID))
+	}
+
+	for _, from := range nodeUUIDs {
+		for _, to := range g.edges[from] {
+			fmt.Fprintf(&sb, "  %s --> %s\n", ids[from], ids[to])
+		}
+	}
+
+	var usedSeverities []string
+	nodesBySeverity := make(map[string][]string)
+	for _, nodeUUID := range nodeUUIDs {
+		if severity, ok := severities[nodeUUID]; ok {
+			if _, seen := nodesBySeverity[severity]; !seen {
+				usedSeverities = append(usedSeverities, severity)
+			}
+			nodesBySeverity[severity] = append(nodesBySeverity[severity], ids[nodeUUID])
+		}
+	}
+	sort.Slice(usedSeverities, func(i, j int) bool {
+		return severityRanks[usedSeverities[i]] > severityRanks[usedSeverities[j]]
+	})
+	for _, severity := range usedSeverities {
+		className := strings.ToLower(severity)
+		fmt.Fprintf(&sb, "  classDef %s fill:%s\n", className, severityColors[severity])
+		fmt.Fprintf(&sb, "  class %s %s\n", strings.Join(nodesBySeverity[severity], ","), className)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// prepareRendering applies opts to the graph, and determines the order in which nodes are rendered:
+// breadth-first from the root, followed by nodes not reachable from the root.
+func (g *DependencyGraph) prepareRendering(opts GraphRenderOptions) (*DependencyGraph, []uuid.UUID, map[uuid.UUID]string) {
+	severities := highestSeverities(opts.Findings)
+
+	if opts.OnlyVulnerablePaths {
+		targets := make([]uuid.UUID, 0, len(severities))
+		for nodeUUID := range severities {
+			targets = append(targets, nodeUUID)
+		}
+		g = g.Subgraph(targets...)
+	}
+
+	nodeUUIDs := append([]uuid.UUID{g.Root}, g.TransitiveDependencies(g.Root)...)
+	seen := make(map[uuid.UUID]bool, len(nodeUUIDs))
+	for _, nodeUUID := range nodeUUIDs {
+		seen[nodeUUID] = true
+	}
+	for _, nodeUUID := range g.sortedNodeUUIDs() {
+		if !seen[nodeUUID] {
+			nodeUUIDs = append(nodeUUIDs, nodeUUID)
+		}
+	}
+
+	return g, nodeUUIDs, severities
+}
+
+// label returns the label of a node: its name and version, separated by sep.
+func (g *DependencyGraph) label(nodeUUID uuid.UUID, sep string) string {
+	name, version := g.labelParts(nodeUUID)
+	if version == "" {
+		return name
+	}
+
+	return name + sep + version
+}
+
+// labelParts returns the name and version to label a node with.
+func (g *DependencyGraph) labelParts(nodeUUID uuid.UUID) (name, version string) {
+	component, ok := g.Nodes[nodeUUID]
+	if !ok || component.Name == "" {
+		return nodeUUID.String(), ""
+	}
+
+	name = component.Name
+	if component.Group != "" {
+		name = component.Group + ":" + name
+	}
+
+	return name, component.Version
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// mermaidReplacer replaces characters that Mermaid would interpret as markup or
+// entity codes in labels. Replacements are not rescanned, so escaped # are left intact.
+var mermaidReplacer = strings.NewReplacer(
+	"#", "#35;",
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+)
+
+func mermaidEscape(s string) string {
+	return mermaidReplacer.Replace(s)
+}
+
+// mermaidLabel returns the escaped label of a node, with name and version
+// separated by a line break.
+func (g *DependencyGraph) mermaidLabel(nodeUUID uuid.UUID) string {
+	name, version := g.labelParts(nodeUUID)
+	if version == "" {
+		return mermaidEscape(name)
+	}
+
+	return mermaidEscape(name) + "<br/>" + mermaidEscape(version)
+}
@@ -0,0 +1,71 @@
+package dtrack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDependencyGraph() (g *DependencyGraph, findings []Finding) {
+	var (
+		rootUUID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		aUUID    = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+		bUUID    = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+		cUUID    = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	)
+
+	// root -> a -> b
+	//      \-> c
+	g = NewDependencyGraph(Component{UUID: rootUUID, Name: "acme-app", Version: "1.0.0"})
+	g.AddNode(Component{UUID: aUUID, Group: "org.acme", Name: "a", Version: "1.0"})
+	g.AddNode(Component{UUID: bUUID, Name: "b \"quoted\"", Version: "2.0"})
+	g.AddNode(Component{UUID: cUUID, Name: "<c#1>"})
+	g.AddEdge(rootUUID, aUUID)
+	g.AddEdge(rootUUID, cUUID)
+	g.AddEdge(aUUID, bUUID)
+
+	findings = []Finding{
+		{Component: Component{UUID: bUUID}, Vulnerability: Vulnerability{Severity: "MEDIUM"}},
+		{Component: Component{UUID: bUUID}, Vulnerability: Vulnerability{Severity: "CRITICAL"}},
+		{Component: Component{UUID: bUUID}, Vulnerability: Vulnerability{Severity: "LOW"}},
+	}
+
+	return
+}
+
+func TestDependencyGraph_WriteDOT(t *testing.T) {
+	g, findings := newTestDependencyGraph()
+
+	var sb strings.Builder
+	require.NoError(t, g.WriteDOT(&sb, GraphRenderOptions{Findings: findings, OnlyVulnerablePaths: true}))
+	require.Equal(t, `digraph "acme-app 1.0.0" {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fillcolor="#ffffff"];
+  "00000000-0000-0000-0000-000000000001" [label="acme-app\n1.0.0", penwidth=2];
+  "00000000-0000-0000-0000-00000000000a" [label="org.acme:a\n1.0"];
+  "00000000-0000-0000-0000-00000000000b" [label="b \"quoted\"\n2.0", fillcolor="#d32f2f", tooltip="CRITICAL"];
+  "00000000-0000-0000-0000-000000000001" -> "00000000-0000-0000-0000-00000000000a";
+  "00000000-0000-0000-0000-00000000000a" -> "00000000-0000-0000-0000-00000000000b";
+}
+`, sb.String())
+}
+
+func TestDependencyGraph_WriteMermaid(t *testing.T) {
+	g, findings := newTestDependencyGraph()
+
+	var sb strings.Builder
+	require.NoError(t, g.WriteMermaid(&sb, GraphRenderOptions{Findings: findings}))
+	require.Equal(t, `flowchart LR
+  n0["acme-app<br/>1.0.0"]
+  n1["org.acme:a<br/>1.0"]
+  n2["#lt;c#35;1#gt;"]
+  n3["b #quot;quoted#quot;<br/>2.0"]
+  n0 --> n1
+  n0 --> n2
+  n1 --> n3
+  classDef critical fill:#d32f2f
+  class n3 critical
+`, sb.String())
+}
@@ -0,0 +1,73 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDirectDependencies(t *testing.T) {
+	refs, err := Project{DirectDependencies: `[{"uuid":"2d16089e-6d3a-437e-b334-f27eb2cbd7f4"},{"uuid":"0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9","name":"log4j-core"}]`}.DirectDependencyRefs()
+	require.NoError(t, err)
+	require.Equal(t, []DependencyRef{
+		{UUID: uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")},
+		{UUID: uuid.MustParse("0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9"), Name: "log4j-core"},
+	}, refs)
+
+	refs, err = Component{}.DirectDependencyRefs()
+	require.NoError(t, err)
+	require.Empty(t, refs)
+
+	_, err = ParseDirectDependencies("[")
+	require.Error(t, err)
+}
+
+func TestProjectService_DependencyGraph(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.9.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var (
+		projectUUID = uuid.MustParse("c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0")
+		aUUID       = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+		bUUID       = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+		cUUID       = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	)
+
+	// project -> a -> b -> c -> b
+	//         \-> c
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/project/%s", projectUUID),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`{"uuid":"%s","name":"acme-app"}`, projectUUID)))
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/dependencyGraph/project/%s/directDependencies", projectUUID),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`[{"uuid":"%s","name":"a"},{"uuid":"%s","name":"c"}]`, aUUID, cUUID)))
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/dependencyGraph/component/%s/directDependencies", aUUID),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`[{"uuid":"%s","name":"b"}]`, bUUID)))
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/dependencyGraph/component/%s/directDependencies", bUUID),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`[{"uuid":"%s","name":"c"}]`, cUUID)))
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/dependencyGraph/component/%s/directDependencies", cUUID),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`[{"uuid":"%s","name":"b"}]`, bUUID)))
+
+	g, err := client.Project.DependencyGraph(context.TODO(), projectUUID)
+	require.NoError(t, err)
+	require.Equal(t, projectUUID, g.Root)
+	require.NotNil(t, g.Project)
+	require.Len(t, g.Nodes, 4)
+	require.Equal(t, "acme-app", g.Nodes[projectUUID].Name)
+	require.Equal(t, 5, httpmock.GetTotalCallCount())
+
+	require.Equal(t, []uuid.UUID{aUUID, cUUID}, g.DirectDependencies(projectUUID))
+	require.Equal(t, []uuid.UUID{aUUID, cUUID, bUUID}, g.TransitiveDependencies(projectUUID))
+	require.Equal(t, []uuid.UUID{cUUID, bUUID}, g.TransitiveDependencies(bUUID))
+	require.Equal(t, []uuid.UUID{aUUID, cUUID}, g.Dependents(bUUID))
+	require.Equal(t, []uuid.UUID{projectUUID, aUUID, bUUID}, g.PathTo(bUUID))
+	require.Equal(t, []uuid.UUID{projectUUID, cUUID}, g.PathTo(cUUID))
+	require.Nil(t, g.PathTo(uuid.New()))
+	require.Equal(t, [][]uuid.UUID{{bUUID, cUUID}}, g.Cycles())
+}
@@ -1,21 +1,88 @@
 package dtrack
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
 )
 
 type APIError struct {
 	StatusCode int
-	Message    string
+	Message    string          // Raw response body
+	Method     string          // Method of the failed request
+	URL        string          // URL of the failed request
+	Header     http.Header     // Headers of the error response
+	Problem    *ProblemDetails // Parsed response body, if the server responded with a JSON problem
+}
+
+// ProblemDetails is a JSON problem description as returned by the server
+// for some errors. See https://www.rfc-editor.org/rfc/rfc7807.
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
 }
 
 func (e APIError) Error() string {
-	if e.Message == "" {
-		return fmt.Sprintf("api error (status: %d)", e.StatusCode)
+	message := e.Message
+	if e.Problem != nil {
+		switch {
+		case e.Problem.Detail != "":
+			message = e.Problem.Detail
+		case e.Problem.Title != "":
+			message = e.Problem.Title
+		}
+	}
+
+	var prefix string
+	if e.Method != "" && e.URL != "" {
+		prefix = fmt.Sprintf("%s %s: ", e.Method, e.URL)
+	}
+
+	if message == "" {
+		return fmt.Sprintf("%sapi error (status: %d)", prefix, e.StatusCode)
+	}
+	return fmt.Sprintf("%s%s (status: %d)", prefix, message, e.StatusCode)
+}
+
+// IsNotFound determines whether err is an APIError with status 404 (Not Found).
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized determines whether err is an APIError with status 401 (Unauthorized).
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden determines whether err is an APIError with status 403 (Forbidden).
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsConflict determines whether err is an APIError with status 409 (Conflict).
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErrPtr *APIError
+	if errors.As(err, &apiErrPtr) {
+		return apiErrPtr != nil && apiErrPtr.StatusCode == statusCode
+	}
+
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == statusCode
 	}
-	return fmt.Sprintf("%s (status: %d)", e.Message, e.StatusCode)
+
+	return false
 }
 
 func checkResponseForError(res *http.Response) error {
@@ -23,12 +90,45 @@ func checkResponseForError(res *http.Response) error {
 		return nil
 	}
 
-	apiErr := &APIError{StatusCode: res.StatusCode}
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+	}
+
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		if res.Request.URL != nil {
+			apiErr.URL = res.Request.URL.String()
+		}
+	}
 
 	body, err := io.ReadAll(res.Body)
 	if err == nil && body != nil {
 		apiErr.Message = string(body)
+		apiErr.Problem = parseProblemDetails(res.Header.Get("Content-Type"), body)
 	}
 
 	return apiErr
 }
+
+// parseProblemDetails attempts to parse body as ProblemDetails.
+// It returns nil when body is not a JSON object.
+func parseProblemDetails(contentType string, body []byte) *ProblemDetails {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "application/json" && mediaType != "application/problem+json" {
+		// Some error responses lack a proper content type.
+		if !strings.HasPrefix(strings.TrimSpace(string(body)), "{") {
+			return nil
+		}
+	}
+
+	var problem ProblemDetails
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return nil
+	}
+	if problem == (ProblemDetails{}) {
+		return nil
+	}
+
+	return &problem
+}
@@ -0,0 +1,67 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResponseForError(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+
+	t.Run("PlainText", func(t *testing.T) {
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/project/%s", projectUUID),
+			httpmock.NewStringResponder(http.StatusNotFound, "The project could not be found."))
+
+		_, err := client.Project.Get(context.TODO(), projectUUID)
+		require.Error(t, err)
+		require.True(t, IsNotFound(err))
+		require.False(t, IsForbidden(err))
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		require.Equal(t, "The project could not be found.", apiErr.Message)
+		require.Equal(t, http.MethodGet, apiErr.Method)
+		require.Equal(t, fmt.Sprintf("http://localhost/api/v1/project/%s", projectUUID), apiErr.URL)
+		require.Nil(t, apiErr.Problem)
+		require.Equal(t, fmt.Sprintf("GET http://localhost/api/v1/project/%s: The project could not be found. (status: 404)", projectUUID), apiErr.Error())
+	})
+
+	t.Run("Problem", func(t *testing.T) {
+		res := httpmock.NewStringResponse(http.StatusConflict, `{"status":409,"title":"Conflict","detail":"A project with the specified name already exists."}`)
+		res.Header.Set("Content-Type", "application/problem+json")
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project", httpmock.ResponderFromResponse(res))
+
+		_, err := client.Project.Create(context.TODO(), Project{Name: "acme-app"})
+		require.Error(t, err)
+		require.True(t, IsConflict(fmt.Errorf("failed to create project: %w", err)))
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.NotNil(t, apiErr.Problem)
+		require.Equal(t, "Conflict", apiErr.Problem.Title)
+		require.Equal(t, "A project with the specified name already exists.", apiErr.Problem.Detail)
+		require.Equal(t, "application/problem+json", apiErr.Header.Get("Content-Type"))
+	})
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	require.True(t, IsUnauthorized(&APIError{StatusCode: http.StatusUnauthorized}))
+	require.True(t, IsUnauthorized(APIError{StatusCode: http.StatusUnauthorized}))
+	require.False(t, IsUnauthorized(&APIError{StatusCode: http.StatusForbidden}))
+	require.False(t, IsUnauthorized(errors.New("unauthorized")))
+	require.False(t, IsUnauthorized(nil))
+}
@@ -0,0 +1,45 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// EventToken identifies an asynchronous event on the server, e.g. the processing of an uploaded BOM.
+// Tokens returned by BOM and VEX uploads are event tokens.
+type EventToken string
+
+type EventService struct {
+	client *Client
+}
+
+// minVersionEventToken is the minimum version of Dependency-Track
+// that can report the processing status of arbitrary events.
+var minVersionEventToken = Version{Major: 4, Minor: 11}
+
+type eventProcessingResponse struct {
+	Processing bool `json:"processing"`
+}
+
+// IsBeingProcessed determines whether the event identified by token is still being processed.
+// It supersedes BOMService.IsBeingProcessed.
+func (es EventService) IsBeingProcessed(ctx context.Context, token EventToken) (bool, error) {
+	err := es.client.requireServerVersion(ctx, minVersionEventToken)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := es.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/event/token/%s", token))
+	if err != nil {
+		return false, err
+	}
+
+	var processingResponse eventProcessingResponse
+	_, err = es.client.doRequest(req, &processingResponse)
+	if err != nil {
+		return false, err
+	}
+
+	return processingResponse.Processing, nil
+}
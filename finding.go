@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -47,3 +49,151 @@ func (f FindingService) GetAll(ctx context.Context, projectUUID uuid.UUID, suppr
 	p.TotalCount = res.TotalCount
 	return
 }
+
+// FindingFilter narrows down portfolio-wide findings.
+// Only non-zero fields are considered.
+//
+// The server does not support filtering by vulnerability source.
+// Use FilterFindingsBySource on the fetched findings instead.
+type FindingFilter struct {
+	Severities       []string        // e.g. CRITICAL, HIGH
+	AnalysisStates   []AnalysisState // e.g. EXPLOITABLE, IN_TRIAGE
+	CVSSV2From       *float64
+	CVSSV2To         *float64
+	CVSSV3From       *float64
+	CVSSV3To         *float64
+	PublishedFrom    time.Time
+	PublishedTo      time.Time
+	TextSearch       string
+	TextSearchFields []string // Fields to search in, e.g. vulnerability.vulnId, component.name
+	ShowInactive     bool     // Include findings of inactive projects
+	ShowSuppressed   bool     // Include suppressed findings; not supported for grouped findings
+}
+
+func (ff FindingFilter) params() map[string]string {
+	params := make(map[string]string)
+
+	if len(ff.Severities) > 0 {
+		params["severity"] = strings.Join(ff.Severities, ",")
+	}
+	if len(ff.AnalysisStates) > 0 {
+		states := make([]string, 0, len(ff.AnalysisStates))
+		for _, state := range ff.AnalysisStates {
+			states = append(states, string(state))
+		}
+		params["analysisStatus"] = strings.Join(states, ",")
+	}
+
+	formatScore := func(score float64) string {
+		return strconv.FormatFloat(score, 'f', -1, 64)
+	}
+	if ff.CVSSV2From != nil {
+		params["cvssv2From"] = formatScore(*ff.CVSSV2From)
+	}
+	if ff.CVSSV2To != nil {
+		params["cvssv2To"] = formatScore(*ff.CVSSV2To)
+	}
+	if ff.CVSSV3From != nil {
+		params["cvssv3From"] = formatScore(*ff.CVSSV3From)
+	}
+	if ff.CVSSV3To != nil {
+		params["cvssv3To"] = formatScore(*ff.CVSSV3To)
+	}
+
+	if !ff.PublishedFrom.IsZero() {
+		params["publishDateFrom"] = ff.PublishedFrom.Format("2006-01-02")
+	}
+	if !ff.PublishedTo.IsZero() {
+		params["publishDateTo"] = ff.PublishedTo.Format("2006-01-02")
+	}
+
+	if ff.TextSearch != "" {
+		params["textSearchInput"] = ff.TextSearch
+		if len(ff.TextSearchFields) > 0 {
+			params["textSearchField"] = strings.Join(ff.TextSearchFields, ",")
+		}
+	}
+
+	if ff.ShowInactive {
+		params["showInactive"] = "true"
+	}
+	if ff.ShowSuppressed {
+		params["showSuppressed"] = "true"
+	}
+
+	return params
+}
+
+// FilterFindingsBySource returns the findings whose vulnerability originates from
+// any of the given sources, e.g. NVD or GITHUB.
+func FilterFindingsBySource(findings []Finding, sources ...string) (filtered []Finding) {
+	for _, finding := range findings {
+		for _, source := range sources {
+			if strings.EqualFold(finding.Vulnerability.Source, source) {
+				filtered = append(filtered, finding)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// minVersionPortfolioFindings is the minimum version of Dependency-Track
+// that provides findings across the entire portfolio.
+var minVersionPortfolioFindings = Version{Major: 4, Minor: 11}
+
+// GetAllPortfolio fetches findings across all projects of the portfolio.
+// The project of each finding is available via Finding.Component.Project.
+func (f FindingService) GetAllPortfolio(ctx context.Context, filter FindingFilter, so SortOptions, po PageOptions) (p Page[Finding], err error) {
+	err = f.client.requireServerVersion(ctx, minVersionPortfolioFindings)
+	if err != nil {
+		return
+	}
+
+	req, err := f.client.newRequest(ctx, http.MethodGet, "/api/v1/finding", withParams(filter.params()), withSortOptions(so), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := f.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// GroupedFinding is a vulnerability, along with the number of projects it affects.
+type GroupedFinding struct {
+	Vulnerability Vulnerability      `json:"vulnerability"` // AffectedProjectCount is populated
+	Attribution   FindingAttribution `json:"attribution"`
+}
+
+// GetAllGrouped fetches findings across all projects of the portfolio, grouped by vulnerability.
+// FindingFilter.AnalysisStates and FindingFilter.ShowSuppressed are not supported by the server
+// in this mode, and thus ignored.
+func (f FindingService) GetAllGrouped(ctx context.Context, filter FindingFilter, so SortOptions, po PageOptions) (p Page[GroupedFinding], err error) {
+	err = f.client.requireServerVersion(ctx, minVersionPortfolioFindings)
+	if err != nil {
+		return
+	}
+
+	params := filter.params()
+	delete(params, "analysisStatus")
+	delete(params, "showSuppressed")
+
+	req, err := f.client.newRequest(ctx, http.MethodGet, "/api/v1/finding/grouped", withParams(params), withSortOptions(so), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := f.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
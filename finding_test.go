@@ -0,0 +1,103 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindingService_GetAllPortfolio(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.11.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/finding",
+		func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			require.Equal(t, "CRITICAL,HIGH", query.Get("severity"))
+			require.Equal(t, "EXPLOITABLE,IN_TRIAGE", query.Get("analysisStatus"))
+			require.Equal(t, "7.5", query.Get("cvssv3From"))
+			require.False(t, query.Has("cvssv3To"))
+			require.Equal(t, "2021-12-01", query.Get("publishDateFrom"))
+			require.Equal(t, "log4j", query.Get("textSearchInput"))
+			require.Equal(t, "component.name", query.Get("textSearchField"))
+			require.Equal(t, "true", query.Get("showInactive"))
+			require.False(t, query.Has("showSuppressed"))
+			require.Equal(t, "vulnerability.severity", query.Get("sortName"))
+			require.Equal(t, "desc", query.Get("sortOrder"))
+			require.Equal(t, "1", query.Get("pageNumber"))
+
+			res := httpmock.NewStringResponse(http.StatusOK, `[{
+	"component": {
+		"uuid": "0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9",
+		"name": "log4j-core",
+		"project": "2d16089e-6d3a-437e-b334-f27eb2cbd7f4",
+		"projectName": "acme-app",
+		"projectVersion": "1.0.0"
+	},
+	"vulnerability": {"vulnId": "CVE-2021-44228", "source": "NVD", "severity": "CRITICAL"},
+	"analysis": {"state": "EXPLOITABLE"}
+}]`)
+			res.Header.Set("X-Total-Count", "1")
+			return res, nil
+		})
+
+	cvssV3From := 7.5
+
+	page, err := client.Finding.GetAllPortfolio(context.TODO(), FindingFilter{
+		Severities:       []string{"CRITICAL", "HIGH"},
+		AnalysisStates:   []AnalysisState{AnalysisStateExploitable, AnalysisStateInTriage},
+		CVSSV3From:       &cvssV3From,
+		PublishedFrom:    time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+		TextSearch:       "log4j",
+		TextSearchFields: []string{"component.name"},
+		ShowInactive:     true,
+	}, SortOptions{Field: "vulnerability.severity", Order: SortOrderDesc}, PageOptions{PageNumber: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.TotalCount)
+	require.Len(t, page.Items, 1)
+
+	finding := page.Items[0]
+	require.NotNil(t, finding.Component.Project)
+	require.Equal(t, "2d16089e-6d3a-437e-b334-f27eb2cbd7f4", finding.Component.Project.UUID.String())
+	require.Equal(t, "acme-app", finding.Component.Project.Name)
+	require.Equal(t, "1.0.0", finding.Component.Project.Version)
+	require.Equal(t, AnalysisStateExploitable, finding.Analysis.State)
+
+	require.Len(t, FilterFindingsBySource(page.Items, "nvd"), 1)
+	require.Empty(t, FilterFindingsBySource(page.Items, "GITHUB"))
+}
+
+func TestFindingService_GetAllGrouped(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.11.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/finding/grouped",
+		func(req *http.Request) (*http.Response, error) {
+			require.False(t, req.URL.Query().Has("analysisStatus"))
+
+			res := httpmock.NewStringResponse(http.StatusOK, `[{
+	"vulnerability": {"vulnId": "CVE-2021-44228", "source": "NVD", "affectedProjectCount": 12},
+	"attribution": {"analyzerIdentity": "INTERNAL_ANALYZER"}
+}]`)
+			res.Header.Set("X-Total-Count", "1")
+			return res, nil
+		})
+
+	page, err := client.Finding.GetAllGrouped(context.TODO(), FindingFilter{
+		AnalysisStates: []AnalysisState{AnalysisStateExploitable},
+	}, SortOptions{}, PageOptions{})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, 12, page.Items[0].Vulnerability.AffectedProjectCount)
+	require.Equal(t, "INTERNAL_ANALYZER", page.Items[0].Attribution.AnalyzerIdentity)
+}
@@ -0,0 +1,70 @@
+//go:build go1.23
+
+package dtrack
+
+import (
+	"context"
+	"iter"
+
+	"github.com/google/uuid"
+)
+
+// All is a convenience function to iterate over all items of a paginated API resource.
+//
+// Pages are fetched lazily, as the iteration progresses. No further pages are fetched
+// once the loop is exited. When fetching a page fails, the error is yielded together
+// with the zero value of T, and the iteration ends.
+func All[T any](pageFetchFunc func(po PageOptions) (Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var (
+			pageNumber = 1
+			itemsSeen  = 0
+		)
+
+		for {
+			page, err := pageFetchFunc(PageOptions{
+				PageNumber: pageNumber,
+				PageSize:   defaultPageSize,
+			})
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for i := range page.Items {
+				if !yield(page.Items[i], nil) {
+					return
+				}
+			}
+
+			itemsSeen += len(page.Items)
+			if len(page.Items) == 0 || itemsSeen >= page.TotalCount {
+				return
+			}
+
+			pageNumber++
+		}
+	}
+}
+
+// All iterates over all projects.
+func (ps ProjectService) All(ctx context.Context) iter.Seq2[Project, error] {
+	return All(func(po PageOptions) (Page[Project], error) {
+		return ps.GetAll(ctx, po)
+	})
+}
+
+// All iterates over all components of a project.
+func (cs ComponentService) All(ctx context.Context, projectUUID uuid.UUID) iter.Seq2[Component, error] {
+	return All(func(po PageOptions) (Page[Component], error) {
+		return cs.GetAll(ctx, projectUUID, po)
+	})
+}
+
+// All iterates over all findings of a project.
+func (f FindingService) All(ctx context.Context, projectUUID uuid.UUID, suppressed bool) iter.Seq2[Finding, error] {
+	return All(func(po PageOptions) (Page[Finding], error) {
+		return f.GetAll(ctx, projectUUID, suppressed, po)
+	})
+}
@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package dtrack_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nscuro/dtrack-client"
+)
+
+// This example demonstrates how to iterate over all findings for a given project.
+func Example_iterateFindings() {
+	client, _ := dtrack.NewClient("https://dtrack.example.com", dtrack.WithAPIKey("..."))
+	projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+
+	for finding, err := range client.Finding.All(context.TODO(), projectUUID, false) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(finding.Vulnerability.VulnID)
+	}
+}
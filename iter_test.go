@@ -0,0 +1,94 @@
+//go:build go1.23
+
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	fetch := newPageFetchFunc(123)
+
+	var (
+		items []int
+		pages []int
+	)
+	for item, err := range All(func(po PageOptions) (Page[int], error) {
+		pages = append(pages, po.PageNumber)
+		return fetch(context.TODO(), po)
+	}) {
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+
+	require.Len(t, items, 123)
+	require.Equal(t, []int{1, 2, 3}, pages)
+}
+
+func TestAll_Break(t *testing.T) {
+	fetch := newPageFetchFunc(1000)
+
+	var pages []int
+	for item, err := range All(func(po PageOptions) (Page[int], error) {
+		pages = append(pages, po.PageNumber)
+		return fetch(context.TODO(), po)
+	}) {
+		require.NoError(t, err)
+		if item == 60 {
+			break
+		}
+	}
+
+	require.Equal(t, []int{1, 2}, pages)
+}
+
+func TestAll_Error(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	var errs []error
+	for _, err := range All(func(po PageOptions) (Page[int], error) {
+		return Page[int]{}, errFetch
+	}) {
+		errs = append(errs, err)
+	}
+
+	require.Equal(t, []error{errFetch}, errs)
+}
+
+func TestComponentService_All(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.New()
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/component/project/%s", projectUUID),
+		func(req *http.Request) (*http.Response, error) {
+			var res *http.Response
+			if req.URL.Query().Get("pageNumber") == "1" {
+				res = httpmock.NewStringResponse(http.StatusOK, `[{"name":"foo"},{"name":"bar"}]`)
+			} else {
+				res = httpmock.NewStringResponse(http.StatusOK, `[]`)
+			}
+			res.Header.Set("X-Total-Count", "2")
+			return res, nil
+		})
+
+	var names []string
+	for component, err := range client.Component.All(context.TODO(), projectUUID) {
+		require.NoError(t, err)
+		names = append(names, component.Name)
+	}
+
+	require.Equal(t, []string{"foo", "bar"}, names)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
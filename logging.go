@@ -0,0 +1,224 @@
+package dtrack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Logger is the interface through which the client emits structured log records.
+//
+// Arguments are alternating keys and values, following the conventions of log/slog.
+// The interface is satisfied by *slog.Logger.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+}
+
+// maxLoggedBodySize is the maximum number of bytes logged for request and response bodies.
+const maxLoggedBodySize = 64 * 1024
+
+// redactedValue replaces sensitive values in log records.
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders are headers whose values are never logged.
+// This includes the headers injected by authHeaderTransport.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// WithLogger enables logging of requests using the given logger.
+//
+// Every request is logged with its method, path, response status, latency and total count.
+// Retries are logged as warnings.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			return fmt.Errorf("no logger provided")
+		}
+
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithBodyLogging toggles whether headers and bodies of requests and responses are logged.
+// Has no effect unless a logger is configured using WithLogger.
+//
+// Credentials are always redacted, but bodies may still contain sensitive information.
+func WithBodyLogging(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.logBodies = enabled
+		return nil
+	}
+}
+
+const contextKeyRedactBodies contextKey = "redactbodies"
+
+// withRedactedBodies marks request and response bodies as sensitive,
+// preventing them from being logged.
+func withRedactedBodies() requestOption {
+	return func(r *http.Request) error {
+		ctx := context.WithValue(r.Context(), contextKeyRedactBodies, true)
+		*r = *(r.WithContext(ctx))
+		return nil
+	}
+}
+
+func isRedactedBody(req *http.Request) bool {
+	redacted, ok := req.Context().Value(contextKeyRedactBodies).(bool)
+	return ok && redacted
+}
+
+// requestLogAttrs assembles the log attributes describing req.
+func (c Client) requestLogAttrs(req *http.Request) []any {
+	attrs := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+	}
+
+	if c.logBodies {
+		attrs = append(attrs, "requestHeaders", redactHeaders(req.Header))
+
+		// Only bodies that can be replayed are logged. Streamed bodies, like multipart uploads, are not.
+		if req.GetBody != nil {
+			if isRedactedBody(req) {
+				attrs = append(attrs, "requestBody", redactedValue)
+			} else if body, err := req.GetBody(); err == nil {
+				content, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodySize))
+				_ = body.Close()
+				attrs = append(attrs, "requestBody", string(content))
+			}
+		}
+	}
+
+	return attrs
+}
+
+// responseLogAttrs assembles the log attributes describing res.
+// When body logging is enabled, at most maxLoggedBodySize bytes of the body of res are buffered,
+// and put back in front of the remaining body so it can still be consumed afterwards.
+func (c Client) responseLogAttrs(req *http.Request, res *http.Response) []any {
+	attrs := []any{"status", res.StatusCode}
+
+	if c.logBodies {
+		attrs = append(attrs, "responseHeaders", redactHeaders(res.Header))
+
+		if isRedactedBody(req) {
+			attrs = append(attrs, "responseBody", redactedValue)
+		} else {
+			prefix, err := io.ReadAll(io.LimitReader(res.Body, maxLoggedBodySize))
+			res.Body = struct {
+				io.Reader
+				io.Closer
+			}{
+				Reader: io.MultiReader(bytes.NewReader(prefix), res.Body),
+				Closer: res.Body,
+			}
+
+			if err == nil {
+				attrs = append(attrs, "responseBody", string(prefix))
+			}
+		}
+	}
+
+	return attrs
+}
+
+// logRequest logs the outcome of a request.
+func (c Client) logRequest(req *http.Request, resAttrs []any, totalCount int, latency time.Duration, err error) {
+	attrs := append(c.requestLogAttrs(req), resAttrs...)
+	attrs = append(attrs, "latency", latency)
+	if totalCount > 0 {
+		attrs = append(attrs, "totalCount", totalCount)
+	}
+
+	if err != nil {
+		attrs = append(attrs, "error", err)
+		c.logger.DebugContext(req.Context(), "request failed", attrs...)
+		return
+	}
+
+	c.logger.DebugContext(req.Context(), "request completed", attrs...)
+}
+
+// logRetry logs that a request is about to be retried.
+func (c Client) logRetry(req *http.Request, res *http.Response, err error, attempt int, backoff time.Duration) {
+	attrs := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+		"attempt", attempt + 1,
+		"backoff", backoff,
+	}
+	if res != nil {
+		attrs = append(attrs, "status", res.StatusCode)
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+
+	c.logger.WarnContext(req.Context(), "retrying request", attrs...)
+}
+
+// redactHeaders creates a copy of header with the values of sensitive headers redacted.
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	if redacted == nil {
+		return http.Header{}
+	}
+
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[http.CanonicalHeaderKey(name)]; ok {
+			redacted.Set(name, redactedValue)
+		}
+	}
+
+	return redacted
+}
+
+// stdLogger is a Logger writing to the standard logger of the log package.
+type stdLogger struct{}
+
+func (stdLogger) DebugContext(_ context.Context, msg string, args ...any) {
+	log.Print(formatLogRecord("DEBUG", msg, args))
+}
+
+func (stdLogger) WarnContext(_ context.Context, msg string, args ...any) {
+	log.Print(formatLogRecord("WARN", msg, args))
+}
+
+func formatLogRecord(level, msg string, args []any) string {
+	sb := strings.Builder{}
+	sb.WriteString(level)
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+
+	for i := 0; i < len(args); i += 2 {
+		key, value := fmt.Sprint(args[i]), any("")
+		if i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		valueStr := fmt.Sprint(value)
+		if strings.ContainsAny(valueStr, " \t\r\n\"=") {
+			valueStr = strconv.Quote(valueStr)
+		}
+
+		sb.WriteString(" ")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(valueStr)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,165 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+type logRecord struct {
+	level string
+	msg   string
+	attrs map[string]any
+}
+
+type recordingLogger struct {
+	mutex   sync.Mutex
+	records []logRecord
+}
+
+func (l *recordingLogger) DebugContext(_ context.Context, msg string, args ...any) {
+	l.record("DEBUG", msg, args)
+}
+
+func (l *recordingLogger) WarnContext(_ context.Context, msg string, args ...any) {
+	l.record("WARN", msg, args)
+}
+
+func (l *recordingLogger) record(level, msg string, args []any) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	attrs := make(map[string]any)
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = args[i+1]
+	}
+
+	l.records = append(l.records, logRecord{level: level, msg: msg, attrs: attrs})
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+
+	client, err := NewClient("http://localhost", WithAPIKey("secret"), WithLogger(logger))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	res := httpmock.NewStringResponse(http.StatusOK, `[{"name":"acme-app"}]`)
+	res.Header.Set("X-Total-Count", "1")
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project", httpmock.ResponderFromResponse(res))
+
+	_, err = client.Project.GetAll(context.TODO(), PageOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, logger.records, 1)
+	record := logger.records[0]
+	require.Equal(t, "DEBUG", record.level)
+	require.Equal(t, "request completed", record.msg)
+	require.Equal(t, http.MethodGet, record.attrs["method"])
+	require.Equal(t, "/api/v1/project", record.attrs["path"])
+	require.Equal(t, http.StatusOK, record.attrs["status"])
+	require.Equal(t, 1, record.attrs["totalCount"])
+	require.Contains(t, record.attrs, "latency")
+	require.NotContains(t, record.attrs, "requestBody")
+	require.NotContains(t, record.attrs, "responseBody")
+}
+
+func TestWithBodyLogging(t *testing.T) {
+	logger := &recordingLogger{}
+
+	client, err := NewClient("http://localhost", WithLogger(logger), WithBodyLogging(true))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("Bodies", func(t *testing.T) {
+		logger.records = nil
+
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project",
+			httpmock.NewStringResponder(http.StatusCreated, `{"name":"acme-app"}`))
+
+		project, err := client.Project.Create(context.TODO(), Project{Name: "acme-app"})
+		require.NoError(t, err)
+		require.Equal(t, "acme-app", project.Name) // Response body must still be consumable
+
+		require.Len(t, logger.records, 1)
+		require.Contains(t, logger.records[0].attrs["requestBody"], `"name":"acme-app"`)
+		require.Equal(t, `{"name":"acme-app"}`, logger.records[0].attrs["responseBody"])
+	})
+
+	t.Run("TeamAPIKeys", func(t *testing.T) {
+		logger.records = nil
+
+		teamUUID := uuid.MustParse("6bd8a7a3-7f0a-4a0a-9b0c-1a1d5a6b7c8d")
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/team/%s", teamUUID),
+			httpmock.NewStringResponder(http.StatusOK, `{"name":"automation","apiKeys":[{"key":"odt_secret"}]}`))
+
+		_, err := client.Team.Get(context.TODO(), teamUUID)
+		require.NoError(t, err)
+
+		require.Len(t, logger.records, 1)
+		require.Equal(t, redactedValue, logger.records[0].attrs["responseBody"])
+	})
+
+	t.Run("LargeBody", func(t *testing.T) {
+		logger.records = nil
+
+		projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+		exported := strings.Repeat("x", maxLoggedBodySize+1024)
+
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/bom/cyclonedx/project/%s", projectUUID),
+			httpmock.NewStringResponder(http.StatusOK, exported))
+
+		var buf strings.Builder
+		written, _, err := client.BOM.ExportProjectTo(context.TODO(), projectUUID, BOMFormatJSON, "", &buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(exported)), written)
+		require.Equal(t, exported, buf.String()) // Logged prefix must not be lost
+
+		require.Len(t, logger.records, 1)
+		require.Equal(t, exported[:maxLoggedBodySize], logger.records[0].attrs["responseBody"])
+	})
+
+	t.Run("Credentials", func(t *testing.T) {
+		logger.records = nil
+
+		httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/user/login",
+			httpmock.NewStringResponder(http.StatusOK, "token"))
+
+		token, err := client.User.Login(context.TODO(), "admin", "password")
+		require.NoError(t, err)
+		require.Equal(t, "token", token)
+
+		require.Len(t, logger.records, 1)
+		require.Equal(t, redactedValue, logger.records[0].attrs["requestBody"])
+		require.Equal(t, redactedValue, logger.records[0].attrs["responseBody"])
+	})
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	header.Set("Authorization", "Bearer token")
+	header.Set("X-Api-Key", "secret")
+
+	redacted := redactHeaders(header)
+	require.Equal(t, "application/json", redacted.Get("Accept"))
+	require.Equal(t, redactedValue, redacted.Get("Authorization"))
+	require.Equal(t, redactedValue, redacted.Get("X-Api-Key"))
+	require.Equal(t, "secret", header.Get("X-Api-Key")) // Original must be unmodified
+}
+
+func TestFormatLogRecord(t *testing.T) {
+	require.Equal(t, `DEBUG request completed method=GET path=/api/v1/project body="{\"a\": 1}"`,
+		formatLogRecord("DEBUG", "request completed", []any{"method", "GET", "path", "/api/v1/project", "body", `{"a": 1}`}))
+}
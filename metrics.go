@@ -77,6 +77,48 @@ type ProjectMetrics struct {
 	PolicyViolationsOperationalUnaudited int     `json:"policyViolationsOperationalUnaudited"`
 }
 
+// Add returns the sum of m and other.
+// FirstOccurrence and LastOccurrence are the earliest and latest occurrence of both, respectively.
+func (m ProjectMetrics) Add(other ProjectMetrics) ProjectMetrics {
+	if m.FirstOccurrence == 0 || (other.FirstOccurrence != 0 && other.FirstOccurrence < m.FirstOccurrence) {
+		m.FirstOccurrence = other.FirstOccurrence
+	}
+	if other.LastOccurrence > m.LastOccurrence {
+		m.LastOccurrence = other.LastOccurrence
+	}
+
+	m.InheritedRiskScore += other.InheritedRiskScore
+	m.Vulnerabilities += other.Vulnerabilities
+	m.VulnerableComponents += other.VulnerableComponents
+	m.Components += other.Components
+	m.Suppressed += other.Suppressed
+	m.Critical += other.Critical
+	m.High += other.High
+	m.Medium += other.Medium
+	m.Low += other.Low
+	m.Unassigned += other.Unassigned
+	m.FindingsTotal += other.FindingsTotal
+	m.FindingsAudited += other.FindingsAudited
+	m.FindingsUnaudited += other.FindingsUnaudited
+	m.PolicyViolationsTotal += other.PolicyViolationsTotal
+	m.PolicyViolationsFail += other.PolicyViolationsFail
+	m.PolicyViolationsWarn += other.PolicyViolationsWarn
+	m.PolicyViolationsInfo += other.PolicyViolationsInfo
+	m.PolicyViolationsAudited += other.PolicyViolationsAudited
+	m.PolicyViolationsUnaudited += other.PolicyViolationsUnaudited
+	m.PolicyViolationsSecurityTotal += other.PolicyViolationsSecurityTotal
+	m.PolicyViolationsSecurityAudited += other.PolicyViolationsSecurityAudited
+	m.PolicyViolationsSecurityUnaudited += other.PolicyViolationsSecurityUnaudited
+	m.PolicyViolationsLicenseTotal += other.PolicyViolationsLicenseTotal
+	m.PolicyViolationsLicenseAudited += other.PolicyViolationsLicenseAudited
+	m.PolicyViolationsLicenseUnaudited += other.PolicyViolationsLicenseUnaudited
+	m.PolicyViolationsOperationalTotal += other.PolicyViolationsOperationalTotal
+	m.PolicyViolationsOperationalAudited += other.PolicyViolationsOperationalAudited
+	m.PolicyViolationsOperationalUnaudited += other.PolicyViolationsOperationalUnaudited
+
+	return m
+}
+
 type MetricsService struct {
 	client *Client
 }
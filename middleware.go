@@ -0,0 +1,96 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+// RequestHandler sends a request on behalf of the operation op and decodes its response.
+//
+// op is the name of the service method that issued the request,
+// without the "Service" suffix of its type (e.g. "Project.GetAll").
+type RequestHandler func(op string, req *http.Request) (APIResponse, error)
+
+// Middleware wraps a RequestHandler with additional behavior.
+// It may modify the request before calling next, and inspect
+// the response or error returned by next.
+type Middleware func(next RequestHandler) RequestHandler
+
+// WithMiddleware registers middlewares that are invoked for every request.
+//
+// Middlewares are run in the order they're provided, i.e. the first middleware
+// is the outermost one. Multiple occurrences of this option are cumulative.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) error {
+		for i, middleware := range middlewares {
+			if middleware == nil {
+				return fmt.Errorf("middleware at index %d is nil", i)
+			}
+		}
+
+		c.middlewares = append(c.middlewares, middlewares...)
+		return nil
+	}
+}
+
+const contextKeyOperation contextKey = "operation"
+
+// withOperation records the name of the operation a request belongs to.
+func withOperation(op string) requestOption {
+	return func(r *http.Request) error {
+		if op == "" {
+			return nil
+		}
+
+		ctx := context.WithValue(r.Context(), contextKeyOperation, op)
+		*r = *(r.WithContext(ctx))
+		return nil
+	}
+}
+
+func operationFromContext(ctx context.Context) string {
+	op, _ := ctx.Value(contextKeyOperation).(string)
+	return op
+}
+
+// callerOperation determines the operation name of the function skip levels
+// above the caller of callerOperation, e.g. "Project.GetAll" for ProjectService.GetAll.
+func callerOperation(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	return operationName(fn.Name())
+}
+
+// operationName converts a fully qualified function name to an operation name.
+func operationName(funcName string) string {
+	name := funcName[strings.LastIndex(funcName, "/")+1:]
+
+	// Strip the package name.
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	// Strip suffixes of closures, e.g. "ProjectService.GetAll.func1".
+	parts := strings.Split(name, ".")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+
+	parts[0] = strings.TrimPrefix(parts[0], "(")
+	parts[0] = strings.TrimSuffix(parts[0], ")")
+	parts[0] = strings.TrimPrefix(parts[0], "*")
+	parts[0] = strings.TrimSuffix(parts[0], "Service")
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,86 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+
+	newMiddleware := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(op string, req *http.Request) (APIResponse, error) {
+				calls = append(calls, name+" before "+op)
+				req.Header.Set("X-Middleware-"+name, "true")
+
+				res, err := next(op, req)
+
+				if err == nil {
+					calls = append(calls, name+" after "+op+" "+res.Status)
+				} else {
+					calls = append(calls, name+" after "+op+" "+err.Error())
+				}
+				return res, err
+			}
+		}
+	}
+
+	client, err := NewClient("http://localhost", WithMiddleware(newMiddleware("A"), newMiddleware("B")))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project",
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "true", req.Header.Get("X-Middleware-A"))
+			require.Equal(t, "true", req.Header.Get("X-Middleware-B"))
+
+			res := httpmock.NewStringResponse(http.StatusOK, `[{"name":"acme-app"}]`)
+			res.Header.Set("X-Total-Count", "1")
+			return res, nil
+		})
+
+	projects, err := client.Project.GetAll(context.TODO(), PageOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, projects.TotalCount)
+	require.Equal(t, []string{
+		"A before Project.GetAll",
+		"B before Project.GetAll",
+		"B after Project.GetAll 200",
+		"A after Project.GetAll 200",
+	}, calls)
+}
+
+func TestWithMiddleware_ShortCircuit(t *testing.T) {
+	errBlocked := errors.New("blocked")
+
+	client, err := NewClient("http://localhost", WithMiddleware(func(next RequestHandler) RequestHandler {
+		return func(op string, req *http.Request) (APIResponse, error) {
+			if op == "About.Get" {
+				return APIResponse{}, errBlocked
+			}
+			return next(op, req)
+		}
+	}))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	_, err = client.About.Get(context.TODO())
+	require.ErrorIs(t, err, errBlocked)
+	require.Equal(t, 0, httpmock.GetTotalCallCount())
+}
+
+func TestOperationName(t *testing.T) {
+	require.Equal(t, "Project.GetAll", operationName("github.com/nscuro/dtrack-client.ProjectService.GetAll"))
+	require.Equal(t, "ViolationAnalysis.Update", operationName("github.com/nscuro/dtrack-client.ViolationAnalysisService.Update"))
+	require.Equal(t, "BOM.Upload", operationName("github.com/nscuro/dtrack-client.(*BOMService).Upload.func1"))
+}
@@ -0,0 +1,93 @@
+package dtrack
+
+import (
+	"compress/gzip"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+)
+
+// multipartBody is a multipart form that is encoded on the fly,
+// while it is being read by the HTTP client.
+type multipartBody struct {
+	reader      *io.PipeReader
+	contentType string
+	gzip        bool
+}
+
+// newMultipartBody creates a multipart form consisting of the given fields,
+// and a file part named fileField whose content is read from file.
+// The form is encoded in a separate goroutine, which exits once the form
+// has been read entirely, or the body has been closed.
+func newMultipartBody(fields map[string]string, fileField string, file io.Reader, gzipped bool) *multipartBody {
+	pipeReader, pipeWriter := io.Pipe()
+
+	var (
+		writer     io.Writer = pipeWriter
+		gzipWriter *gzip.Writer
+	)
+	if gzipped {
+		gzipWriter = gzip.NewWriter(pipeWriter)
+		writer = gzipWriter
+	}
+
+	multipartWriter := multipart.NewWriter(writer)
+	body := &multipartBody{
+		reader:      pipeReader,
+		contentType: multipartWriter.FormDataContentType(),
+		gzip:        gzipped,
+	}
+
+	go func() {
+		err := writeMultipartForm(multipartWriter, fields, fileField, file)
+		if err == nil && gzipWriter != nil {
+			err = gzipWriter.Close()
+		}
+		_ = pipeWriter.CloseWithError(err)
+	}()
+
+	return body
+}
+
+func writeMultipartForm(writer *multipart.Writer, fields map[string]string, fileField string, file io.Reader) error {
+	fieldNames := make([]string, 0, len(fields))
+	for fieldName := range fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		if err := writer.WriteField(fieldName, fields[fieldName]); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile(fileField, fileField)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, file); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// Close stops the encoding of the form.
+func (mb *multipartBody) Close() error {
+	return mb.reader.Close()
+}
+
+func withMultipartBody(body *multipartBody) requestOption {
+	return func(req *http.Request) error {
+		req.Body = body.reader
+		req.ContentLength = -1 // Unknown, the body is streamed
+		req.Header.Set("Content-Type", body.contentType)
+		if body.gzip {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+module github.com/nscuro/dtrack-client/otel
+
+go 1.20
+
+require (
+	github.com/google/uuid v1.3.0
+	github.com/nscuro/dtrack-client v0.14.0
+	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/metric v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/sdk/metric v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Build against the parent module during local development.
+// Replace directives are ignored by dependents, which use the release required above.
+// When releasing, require the upcoming root module release above,
+// and tag both modules on the same commit (vX.Y.Z and otel/vX.Y.Z).
+replace github.com/nscuro/dtrack-client => ../
@@ -0,0 +1,175 @@
+package otel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nscuro/dtrack-client"
+)
+
+const (
+	AttributeOperation   = attribute.Key("dtrack.operation")
+	AttributeProjectUUID = attribute.Key("dtrack.project.uuid")
+	AttributePageNumber  = attribute.Key("dtrack.page.number")
+	AttributePageSize    = attribute.Key("dtrack.page.size")
+	AttributeTotalCount  = attribute.Key("dtrack.total_count")
+
+	attributeHTTPMethod     = attribute.Key("http.request.method")
+	attributeHTTPStatusCode = attribute.Key("http.response.status_code")
+	attributeServerAddress  = attribute.Key("server.address")
+	attributeURLFull        = attribute.Key("url.full")
+)
+
+const (
+	MetricRequests        = "dtrack.client.requests"
+	MetricRequestDuration = "dtrack.client.request.duration"
+)
+
+// NewMiddleware creates a dtrack.Middleware that traces and measures all requests of a client.
+func NewMiddleware(options ...Option) (dtrack.Middleware, error) {
+	cfg := newConfig(options)
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	requestCounter, err := meter.Int64Counter(MetricRequests,
+		metric.WithDescription("Number of requests sent to the Dependency-Track API."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	durationHistogram, err := meter.Float64Histogram(MetricRequestDuration,
+		metric.WithDescription("Duration of requests sent to the Dependency-Track API."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	m := middleware{
+		tracer:            tracer,
+		propagator:        cfg.propagator,
+		requestCounter:    requestCounter,
+		durationHistogram: durationHistogram,
+	}
+
+	return m.wrap, nil
+}
+
+type middleware struct {
+	tracer            trace.Tracer
+	propagator        propagation.TextMapPropagator
+	requestCounter    metric.Int64Counter
+	durationHistogram metric.Float64Histogram
+}
+
+func (m middleware) wrap(next dtrack.RequestHandler) dtrack.RequestHandler {
+	return func(op string, req *http.Request) (dtrack.APIResponse, error) {
+		spanName := op
+		if spanName == "" {
+			spanName = req.Method
+		}
+
+		ctx, span := m.tracer.Start(req.Context(), spanName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(requestAttributes(op, req)...))
+		defer span.End()
+
+		req = req.WithContext(ctx)
+		m.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		start := time.Now()
+		res, err := next(op, req)
+		duration := time.Since(start)
+
+		metricAttrs := []attribute.KeyValue{
+			AttributeOperation.String(op),
+			attributeHTTPMethod.String(req.Method),
+		}
+
+		if res.Response != nil {
+			span.SetAttributes(attributeHTTPStatusCode.Int(res.StatusCode))
+			metricAttrs = append(metricAttrs, attributeHTTPStatusCode.Int(res.StatusCode))
+			if res.TotalCount > 0 {
+				span.SetAttributes(AttributeTotalCount.Int(res.TotalCount))
+			}
+		} else if statusCode := errorStatusCode(err); statusCode > 0 {
+			span.SetAttributes(attributeHTTPStatusCode.Int(statusCode))
+			metricAttrs = append(metricAttrs, attributeHTTPStatusCode.Int(statusCode))
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		m.requestCounter.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+		m.durationHistogram.Record(ctx, duration.Seconds(), metric.WithAttributes(metricAttrs...))
+
+		return res, err
+	}
+}
+
+// requestAttributes assembles span attributes describing req.
+func requestAttributes(op string, req *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		AttributeOperation.String(op),
+		attributeHTTPMethod.String(req.Method),
+		attributeServerAddress.String(req.URL.Hostname()),
+		attributeURLFull.String(req.URL.String()),
+	}
+
+	if projectUUID, ok := projectUUIDFromRequest(req); ok {
+		attrs = append(attrs, AttributeProjectUUID.String(projectUUID.String()))
+	}
+
+	query := req.URL.Query()
+	if pageNumber, err := strconv.Atoi(query.Get("pageNumber")); err == nil {
+		attrs = append(attrs, AttributePageNumber.Int(pageNumber))
+	}
+	if pageSize, err := strconv.Atoi(query.Get("pageSize")); err == nil {
+		attrs = append(attrs, AttributePageSize.Int(pageSize))
+	}
+
+	return attrs
+}
+
+// projectUUIDFromRequest attempts to determine the UUID of the project a request refers to.
+// The UUID is looked up in paths like /api/v1/project/{uuid} or /api/v1/finding/project/{uuid},
+// and in the "project" query parameter.
+func projectUUIDFromRequest(req *http.Request) (uuid.UUID, bool) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] != "project" {
+			continue
+		}
+		if projectUUID, err := uuid.Parse(segments[i+1]); err == nil {
+			return projectUUID, true
+		}
+	}
+
+	if projectUUID, err := uuid.Parse(req.URL.Query().Get("project")); err == nil {
+		return projectUUID, true
+	}
+
+	return uuid.Nil, false
+}
+
+func errorStatusCode(err error) int {
+	var apiErr *dtrack.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
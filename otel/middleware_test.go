@@ -0,0 +1,100 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nscuro/dtrack-client"
+)
+
+func TestNewMiddleware(t *testing.T) {
+	projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+
+		switch r.URL.Path {
+		case "/api/v1/component/project/" + projectUUID.String():
+			w.Header().Set("X-Total-Count", "1")
+			_, _ = w.Write([]byte(`[{"name":"foo","version":"1.0.0"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	metricReader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+
+	mw, err := NewMiddleware(
+		WithTracerProvider(tracerProvider),
+		WithMeterProvider(meterProvider),
+		WithPropagator(propagation.TraceContext{}))
+	require.NoError(t, err)
+
+	client, err := dtrack.NewClient(server.URL, dtrack.WithMiddleware(mw))
+	require.NoError(t, err)
+
+	_, err = client.Component.GetAll(context.TODO(), projectUUID, dtrack.PageOptions{PageNumber: 2, PageSize: 10})
+	require.NoError(t, err)
+
+	_, err = client.Project.Get(context.TODO(), uuid.New())
+	require.Error(t, err)
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	require.Equal(t, "Component.GetAll", span.Name())
+	require.Equal(t, trace.SpanKindClient, span.SpanKind())
+	require.Equal(t, codes.Unset, span.Status().Code)
+	require.Contains(t, span.Attributes(), AttributeOperation.String("Component.GetAll"))
+	require.Contains(t, span.Attributes(), AttributeProjectUUID.String(projectUUID.String()))
+	require.Contains(t, span.Attributes(), AttributePageNumber.Int(2))
+	require.Contains(t, span.Attributes(), AttributePageSize.Int(10))
+	require.Contains(t, span.Attributes(), AttributeTotalCount.Int(1))
+	require.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+
+	span = spans[1]
+	require.Equal(t, "Project.Get", span.Name())
+	require.Equal(t, codes.Error, span.Status().Code)
+	require.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusNotFound))
+	require.Contains(t, traceparent, span.SpanContext().TraceID().String())
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, metricReader.Collect(context.TODO(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+	require.Equal(t, ScopeName, metrics.ScopeMetrics[0].Scope.Name)
+
+	metricsByName := make(map[string]metricdata.Metrics)
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		metricsByName[m.Name] = m
+	}
+
+	requests, ok := metricsByName[MetricRequests].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, requests.DataPoints, 2)
+
+	durations, ok := metricsByName[MetricRequestDuration].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, durations.DataPoints, 2)
+	for _, dp := range durations.DataPoints {
+		require.Equal(t, uint64(1), dp.Count)
+	}
+}
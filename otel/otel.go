@@ -0,0 +1,73 @@
+// Package otel provides OpenTelemetry instrumentation for the Dependency-Track client.
+//
+// Instrumentation is implemented as dtrack.Middleware, and emits one span per service call,
+// as well as metrics about the number and latency of requests:
+//
+//	mw, err := otel.NewMiddleware()
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	client, err := dtrack.NewClient("https://dtrack.example.com", dtrack.WithMiddleware(mw))
+//
+// Unless configured otherwise, the global TracerProvider, MeterProvider and TextMapPropagator are used.
+package otel
+
+import (
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name used for tracers and meters.
+const ScopeName = "github.com/nscuro/dtrack-client/otel"
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+func newConfig(options []Option) config {
+	cfg := config{
+		tracerProvider: otelapi.GetTracerProvider(),
+		meterProvider:  otelapi.GetMeterProvider(),
+		propagator:     otelapi.GetTextMapPropagator(),
+	}
+
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	return cfg
+}
+
+type Option func(*config)
+
+// WithTracerProvider overrides the TracerProvider used to create spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		if tp != nil {
+			c.tracerProvider = tp
+		}
+	}
+}
+
+// WithMeterProvider overrides the MeterProvider used to record metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		if mp != nil {
+			c.meterProvider = mp
+		}
+	}
+}
+
+// WithPropagator overrides the propagator used to inject the trace context into request headers.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		if p != nil {
+			c.propagator = p
+		}
+	}
+}
@@ -4,28 +4,40 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/google/uuid"
 )
 
 type Project struct {
-	UUID               uuid.UUID         `json:"uuid,omitempty"`
-	Author             string            `json:"author,omitempty"`
-	Publisher          string            `json:"publisher,omitempty"`
-	Group              string            `json:"group,omitempty"`
-	Name               string            `json:"name,omitempty"`
-	Description        string            `json:"description,omitempty"`
-	Version            string            `json:"version,omitempty"`
-	Classifier         string            `json:"classifier,omitempty"`
-	CPE                string            `json:"cpe,omitempty"`
-	PURL               string            `json:"purl,omitempty"`
-	SWIDTagID          string            `json:"swidTagId,omitempty"`
-	DirectDependencies string            `json:"directDependencies,omitempty"`
-	Properties         []ProjectProperty `json:"properties,omitempty"`
-	Tags               []Tag             `json:"tags,omitempty"`
-	Active             bool              `json:"active"`
-	Metrics            ProjectMetrics    `json:"metrics"`
-	LastBOMImport      int               `json:"lastBomImport"`
+	UUID                   uuid.UUID         `json:"uuid,omitempty"`
+	Author                 string            `json:"author,omitempty"`
+	Publisher              string            `json:"publisher,omitempty"`
+	Group                  string            `json:"group,omitempty"`
+	Name                   string            `json:"name,omitempty"`
+	Description            string            `json:"description,omitempty"`
+	Version                string            `json:"version,omitempty"`
+	Classifier             string            `json:"classifier,omitempty"`
+	CPE                    string            `json:"cpe,omitempty"`
+	PURL                   string            `json:"purl,omitempty"`
+	SWIDTagID              string            `json:"swidTagId,omitempty"`
+	DirectDependencies     string            `json:"directDependencies,omitempty"`
+	Properties             []ProjectProperty `json:"properties,omitempty"`
+	Tags                   []Tag             `json:"tags,omitempty"`
+	Active                 bool              `json:"active"`
+	Metrics                ProjectMetrics    `json:"metrics"`
+	Parent                 *ParentRef        `json:"parent,omitempty"`
+	Children               []Project         `json:"children,omitempty"`
+	LastBOMImport          int               `json:"lastBomImport"`
+	LastBOMImportFormat    string            `json:"lastBomImportFormat,omitempty"`
+	LastInheritedRiskScore float64           `json:"lastInheritedRiskScore,omitempty"`
+}
+
+// ParentRef references the parent of a Project.
+type ParentRef struct {
+	UUID    uuid.UUID `json:"uuid,omitempty"`
+	Name    string    `json:"name,omitempty"`
+	Version string    `json:"version,omitempty"`
 }
 
 type ProjectService struct {
@@ -57,6 +69,128 @@ func (ps ProjectService) GetAll(ctx context.Context, po PageOptions) (p Page[Pro
 	return
 }
 
+// GetAllActive is like GetAll, but excludes inactive projects.
+func (ps ProjectService) GetAllActive(ctx context.Context, po PageOptions) (p Page[Project], err error) {
+	req, err := ps.client.newRequest(ctx, http.MethodGet, "/api/v1/project", withParams(projectFilterParams(true)), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := ps.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// GetAllByName fetches all projects with the given name, i.e. all versions of a project.
+func (ps ProjectService) GetAllByName(ctx context.Context, name string, excludeInactive bool, po PageOptions) (p Page[Project], err error) {
+	params := projectFilterParams(excludeInactive)
+	params["name"] = name
+
+	req, err := ps.client.newRequest(ctx, http.MethodGet, "/api/v1/project", withParams(params), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := ps.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+func (ps ProjectService) GetAllByTag(ctx context.Context, tag string, excludeInactive bool, po PageOptions) (p Page[Project], err error) {
+	req, err := ps.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/project/tag/%s", url.PathEscape(tag)),
+		withParams(projectFilterParams(excludeInactive)), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := ps.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+func (ps ProjectService) GetAllByClassifier(ctx context.Context, classifier string, excludeInactive bool, po PageOptions) (p Page[Project], err error) {
+	req, err := ps.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/project/classifier/%s", url.PathEscape(classifier)),
+		withParams(projectFilterParams(excludeInactive)), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := ps.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// minVersionProjectHierarchy is the minimum version of Dependency-Track that supports
+// parent and child projects.
+var minVersionProjectHierarchy = Version{Major: 4, Minor: 7}
+
+// GetChildren fetches the direct children of a project.
+func (ps ProjectService) GetChildren(ctx context.Context, projectUUID uuid.UUID, excludeInactive bool, po PageOptions) (p Page[Project], err error) {
+	err = ps.client.requireServerVersion(ctx, minVersionProjectHierarchy)
+	if err != nil {
+		return
+	}
+
+	req, err := ps.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/project/%s/children", projectUUID),
+		withParams(projectFilterParams(excludeInactive)), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := ps.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// minVersionProjectLatest is the minimum version of Dependency-Track that
+// keeps track of the latest version of a project.
+var minVersionProjectLatest = Version{Major: 4, Minor: 12}
+
+// GetLatest fetches the version of the project with the given name that is marked as latest.
+func (ps ProjectService) GetLatest(ctx context.Context, name string) (p Project, err error) {
+	err = ps.client.requireServerVersion(ctx, minVersionProjectLatest)
+	if err != nil {
+		return
+	}
+
+	req, err := ps.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/project/latest/%s", url.PathEscape(name)))
+	if err != nil {
+		return
+	}
+
+	_, err = ps.client.doRequest(req, &p)
+	return
+}
+
+func projectFilterParams(excludeInactive bool) map[string]string {
+	params := make(map[string]string)
+	if excludeInactive {
+		params["excludeInactive"] = "true"
+	}
+
+	return params
+}
+
 func (ps ProjectService) Create(ctx context.Context, project Project) (p Project, err error) {
 	req, err := ps.client.newRequest(ctx, http.MethodPut, "/api/v1/project", withBody(project))
 	if err != nil {
@@ -0,0 +1,79 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_GetAllByTag(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/tag/team%2Fa?excludeInactive=true&pageNumber=1&pageSize=10",
+		func(req *http.Request) (*http.Response, error) {
+			res := httpmock.NewStringResponse(http.StatusOK, `[{
+	"uuid": "2d16089e-6d3a-437e-b334-f27eb2cbd7f4",
+	"name": "acme-app",
+	"parent": {"uuid": "c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0", "name": "acme"},
+	"lastBomImportFormat": "CycloneDX 1.4",
+	"lastInheritedRiskScore": 12.5
+}]`)
+			res.Header.Set("X-Total-Count", "1")
+			return res, nil
+		})
+
+	page, err := client.Project.GetAllByTag(context.TODO(), "team/a", true, PageOptions{PageNumber: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.TotalCount)
+	require.Len(t, page.Items, 1)
+
+	project := page.Items[0]
+	require.Equal(t, "acme-app", project.Name)
+	require.Equal(t, &ParentRef{UUID: uuid.MustParse("c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0"), Name: "acme"}, project.Parent)
+	require.Equal(t, "CycloneDX 1.4", project.LastBOMImportFormat)
+	require.Equal(t, 12.5, project.LastInheritedRiskScore)
+}
+
+func TestProjectService_GetChildren(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.7.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0")
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+projectUUID.String()+"/children",
+		func(req *http.Request) (*http.Response, error) {
+			require.Empty(t, req.URL.Query().Get("excludeInactive"))
+			res := httpmock.NewStringResponse(http.StatusOK, `[{"name":"a"},{"name":"b"}]`)
+			res.Header.Set("X-Total-Count", "2")
+			return res, nil
+		})
+
+	page, err := client.Project.GetChildren(context.TODO(), projectUUID, false, PageOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, page.TotalCount)
+	require.Len(t, page.Items, 2)
+}
+
+func TestProjectService_GetLatest_UnsupportedServerVersion(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.11.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	_, err = client.Project.GetLatest(context.TODO(), "acme-app")
+	require.True(t, errors.Is(err, ErrUnsupportedServerVersion))
+	require.Equal(t, 0, httpmock.GetTotalCallCount())
+}
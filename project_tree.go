@@ -0,0 +1,131 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ProjectNode is a node in a tree of projects, as built by ProjectService.Tree.
+type ProjectNode struct {
+	Project  Project
+	Parent   *ProjectNode // Parent node, nil for the root
+	Children []*ProjectNode
+	Depth    int // Distance to the root, which has a depth of 0
+}
+
+// Tree builds a tree of the project identified by rootUUID and all its descendants.
+//
+// The metrics of each node are the latest metrics reported by the server for the respective project.
+// Use ProjectNode.RollupMetrics to aggregate them.
+func (ps ProjectService) Tree(ctx context.Context, rootUUID uuid.UUID) (root *ProjectNode, err error) {
+	project, err := ps.Get(ctx, rootUUID)
+	if err != nil {
+		return
+	}
+
+	root = &ProjectNode{Project: project}
+
+	// The server does not allow cycles in the hierarchy,
+	// but guard against them nevertheless so we don't loop forever.
+	visited := map[uuid.UUID]bool{rootUUID: true}
+
+	queue := []*ProjectNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		var children []Project
+		children, err = FetchAll(func(po PageOptions) (Page[Project], error) {
+			return ps.GetChildren(ctx, node.Project.UUID, false, po)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			if visited[child.UUID] {
+				continue
+			}
+			visited[child.UUID] = true
+
+			childNode := &ProjectNode{
+				Project: child,
+				Parent:  node,
+				Depth:   node.Depth + 1,
+			}
+			node.Children = append(node.Children, childNode)
+			queue = append(queue, childNode)
+		}
+	}
+
+	return
+}
+
+// SkipChildren may be returned by a ProjectWalkFunc to skip the children of the current node.
+var SkipChildren = errors.New("skip children")
+
+// ProjectWalkFunc is called by ProjectNode.Walk for every visited node.
+//
+// When SkipChildren is returned, the children of node are not visited.
+// Any other error aborts the walk and is returned by Walk.
+type ProjectWalkFunc func(node *ProjectNode) error
+
+// Walk visits n and its descendants depth-first, parents before their children.
+//
+// Nodes deeper than maxDepth, relative to n, are not visited. A negative maxDepth means no limit.
+func (n *ProjectNode) Walk(maxDepth int, walkFunc ProjectWalkFunc) error {
+	err := n.walk(0, maxDepth, walkFunc)
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+
+	return err
+}
+
+func (n *ProjectNode) walk(depth, maxDepth int, walkFunc ProjectWalkFunc) error {
+	if err := walkFunc(n); err != nil {
+		return err
+	}
+
+	if maxDepth >= 0 && depth >= maxDepth {
+		return nil
+	}
+
+	for _, child := range n.Children {
+		err := child.walk(depth+1, maxDepth, walkFunc)
+		if err != nil && !errors.Is(err, SkipChildren) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RollupMetrics aggregates the metrics of n's project and the projects of all its descendants.
+func (n *ProjectNode) RollupMetrics() ProjectMetrics {
+	metrics := n.Project.Metrics
+	for _, child := range n.Children {
+		metrics = metrics.Add(child.RollupMetrics())
+	}
+
+	return metrics
+}
+
+// RollupMetricsByProject aggregates metrics like RollupMetrics does, for n and each of its descendants.
+func (n *ProjectNode) RollupMetricsByProject() map[uuid.UUID]ProjectMetrics {
+	metrics := make(map[uuid.UUID]ProjectMetrics)
+	n.rollupMetrics(metrics)
+	return metrics
+}
+
+func (n *ProjectNode) rollupMetrics(metricsByProject map[uuid.UUID]ProjectMetrics) ProjectMetrics {
+	metrics := n.Project.Metrics
+	for _, child := range n.Children {
+		metrics = metrics.Add(child.rollupMetrics(metricsByProject))
+	}
+
+	metricsByProject[n.Project.UUID] = metrics
+	return metrics
+}
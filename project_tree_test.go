@@ -0,0 +1,100 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_Tree(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.7.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var (
+		rootUUID       = uuid.MustParse("c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0")
+		childAUUID     = uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+		childBUUID     = uuid.MustParse("0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9")
+		grandchildUUID = uuid.MustParse("7a5c3f83-3e9e-4f0b-8a4b-b35a4e1b4c7e")
+	)
+
+	registerChildren := func(parentUUID uuid.UUID, count int, children string) {
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/project/%s/children", parentUUID),
+			func(req *http.Request) (*http.Response, error) {
+				res := httpmock.NewStringResponse(http.StatusOK, children)
+				res.Header.Set("X-Total-Count", fmt.Sprintf("%d", count))
+				return res, nil
+			})
+	}
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("http://localhost/api/v1/project/%s", rootUUID),
+		httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(`{"uuid":"%s","name":"root","metrics":{"critical":1,"firstOccurrence":200}}`, rootUUID)))
+	registerChildren(rootUUID, 2, fmt.Sprintf(`[
+	{"uuid":"%s","name":"a","metrics":{"critical":2,"high":1,"firstOccurrence":100}},
+	{"uuid":"%s","name":"b","metrics":{"high":3}}
+]`, childAUUID, childBUUID))
+	registerChildren(childAUUID, 1, fmt.Sprintf(`[{"uuid":"%s","name":"a1","metrics":{"critical":4}}]`, grandchildUUID))
+	registerChildren(childBUUID, 0, `[]`)
+	registerChildren(grandchildUUID, 0, `[]`)
+
+	root, err := client.Project.Tree(context.TODO(), rootUUID)
+	require.NoError(t, err)
+	require.Equal(t, "root", root.Project.Name)
+	require.Len(t, root.Children, 2)
+	require.Len(t, root.Children[0].Children, 1)
+	require.Equal(t, root.Children[0], root.Children[0].Children[0].Parent)
+	require.Equal(t, 2, root.Children[0].Children[0].Depth)
+
+	t.Run("Walk", func(t *testing.T) {
+		var visited []string
+		err := root.Walk(-1, func(node *ProjectNode) error {
+			visited = append(visited, node.Project.Name)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"root", "a", "a1", "b"}, visited)
+	})
+
+	t.Run("WalkMaxDepth", func(t *testing.T) {
+		var visited []string
+		err := root.Walk(1, func(node *ProjectNode) error {
+			visited = append(visited, node.Project.Name)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"root", "a", "b"}, visited)
+	})
+
+	t.Run("WalkSkipChildren", func(t *testing.T) {
+		var visited []string
+		err := root.Walk(-1, func(node *ProjectNode) error {
+			visited = append(visited, node.Project.Name)
+			if node.Project.Name == "a" {
+				return SkipChildren
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"root", "a", "b"}, visited)
+	})
+
+	t.Run("RollupMetrics", func(t *testing.T) {
+		metrics := root.RollupMetrics()
+		require.Equal(t, 7, metrics.Critical)
+		require.Equal(t, 4, metrics.High)
+		require.Equal(t, 100, metrics.FirstOccurrence)
+
+		metricsByProject := root.RollupMetricsByProject()
+		require.Len(t, metricsByProject, 4)
+		require.Equal(t, metrics, metricsByProject[rootUUID])
+		require.Equal(t, 6, metricsByProject[childAUUID].Critical)
+		require.Equal(t, 3, metricsByProject[childBUUID].High)
+	})
+}
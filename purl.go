@@ -0,0 +1,138 @@
+package dtrack
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// PackageURL is a parsed Package URL (purl), as specified by https://github.com/package-url/purl-spec.
+type PackageURL struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+	Subpath    string
+}
+
+// ParsePackageURL parses a Package URL of the form
+// pkg:type/namespace/name@version?qualifiers#subpath.
+func ParsePackageURL(s string) (purl PackageURL, err error) {
+	remainder := strings.TrimSpace(s)
+	if !strings.HasPrefix(strings.ToLower(remainder), "pkg:") {
+		err = fmt.Errorf("invalid package url %q: scheme must be pkg", s)
+		return
+	}
+	remainder = strings.TrimLeft(remainder[len("pkg:"):], "/")
+
+	if i := strings.LastIndex(remainder, "#"); i >= 0 {
+		purl.Subpath = strings.Trim(remainder[i+1:], "/")
+		remainder = remainder[:i]
+	}
+
+	if i := strings.LastIndex(remainder, "?"); i >= 0 {
+		var query url.Values
+		query, err = url.ParseQuery(remainder[i+1:])
+		if err != nil {
+			err = fmt.Errorf("invalid package url %q: invalid qualifiers: %w", s, err)
+			return
+		}
+
+		purl.Qualifiers = make(map[string]string, len(query))
+		for key := range query {
+			purl.Qualifiers[strings.ToLower(key)] = query.Get(key)
+		}
+		remainder = remainder[:i]
+	}
+
+	// The version follows the last @ of the name, which itself follows the last slash.
+	// Namespaces may contain an @, e.g. scoped npm packages.
+	if i := strings.LastIndex(remainder, "@"); i > strings.LastIndex(remainder, "/") {
+		purl.Version, err = url.PathUnescape(remainder[i+1:])
+		if err != nil {
+			err = fmt.Errorf("invalid package url %q: invalid version: %w", s, err)
+			return
+		}
+		remainder = remainder[:i]
+	}
+
+	segments := strings.Split(strings.Trim(remainder, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" {
+		err = fmt.Errorf("invalid package url %q: type and name are required", s)
+		return
+	}
+	purl.Type = strings.ToLower(segments[0])
+
+	for i, segment := range segments[1:] {
+		segments[i+1], err = url.PathUnescape(segment)
+		if err != nil {
+			err = fmt.Errorf("invalid package url %q: %w", s, err)
+			return
+		}
+	}
+
+	purl.Name = segments[len(segments)-1]
+	purl.Namespace = strings.Join(segments[1:len(segments)-1], "/")
+	if purl.Name == "" {
+		err = fmt.Errorf("invalid package url %q: name is required", s)
+	}
+
+	return
+}
+
+// String returns the canonical representation of the Package URL.
+func (p PackageURL) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("pkg:")
+	sb.WriteString(p.Type)
+	sb.WriteString("/")
+	if p.Namespace != "" {
+		for _, segment := range strings.Split(p.Namespace, "/") {
+			sb.WriteString(url.PathEscape(segment))
+			sb.WriteString("/")
+		}
+	}
+	sb.WriteString(url.PathEscape(p.Name))
+
+	if p.Version != "" {
+		sb.WriteString("@")
+		sb.WriteString(url.PathEscape(p.Version))
+	}
+
+	if len(p.Qualifiers) > 0 {
+		keys := make([]string, 0, len(p.Qualifiers))
+		for key := range p.Qualifiers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for i, key := range keys {
+			if i == 0 {
+				sb.WriteString("?")
+			} else {
+				sb.WriteString("&")
+			}
+			sb.WriteString(key)
+			sb.WriteString("=")
+			sb.WriteString(url.QueryEscape(p.Qualifiers[key]))
+		}
+	}
+
+	if p.Subpath != "" {
+		sb.WriteString("#")
+		sb.WriteString(p.Subpath)
+	}
+
+	return sb.String()
+}
+
+// Matches reports whether other refers to the same package as p,
+// i.e. whether type, namespace and name are equal. Versions are not compared.
+func (p PackageURL) Matches(other PackageURL) bool {
+	return p.Type == other.Type &&
+		strings.EqualFold(p.Namespace, other.Namespace) &&
+		strings.EqualFold(p.Name, other.Name)
+}
@@ -0,0 +1,52 @@
+package dtrack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePackageURL(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected PackageURL
+		str      string
+	}{
+		{
+			input:    "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar",
+			expected: PackageURL{Type: "maven", Namespace: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1", Qualifiers: map[string]string{"type": "jar"}},
+		},
+		{
+			input:    "pkg:npm/%40angular/core@12.0.0",
+			expected: PackageURL{Type: "npm", Namespace: "@angular", Name: "core", Version: "12.0.0"},
+			str:      "pkg:npm/@angular/core@12.0.0",
+		},
+		{
+			input:    "pkg:npm/@angular/core",
+			expected: PackageURL{Type: "npm", Namespace: "@angular", Name: "core"},
+		},
+		{
+			input:    "pkg:golang/github.com/google/uuid@v1.3.0#subpkg",
+			expected: PackageURL{Type: "golang", Namespace: "github.com/google", Name: "uuid", Version: "v1.3.0", Subpath: "subpkg"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			purl, err := ParsePackageURL(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, purl)
+
+			expectedStr := tc.str
+			if expectedStr == "" {
+				expectedStr = tc.input
+			}
+			require.Equal(t, expectedStr, purl.String())
+		})
+	}
+
+	for _, input := range []string{"", "maven/foo/bar", "pkg:maven", "pkg:maven/@1.0"} {
+		_, err := ParsePackageURL(input)
+		require.Error(t, err, input)
+	}
+}
@@ -0,0 +1,113 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the rate at which requests are sent to rps requests per second,
+// allowing bursts of up to burst requests. Retries count towards the limit.
+//
+// The limit applies to all services of the client. Requests exceeding the limit
+// block until they're permitted, or until their context is done.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if rps <= 0 {
+			return fmt.Errorf("rate limit must be greater than zero")
+		}
+		if burst <= 0 {
+			return fmt.Errorf("burst must be greater than zero")
+		}
+
+		c.rateLimiter = newRateLimiter(rps, burst)
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests that may be in flight at the same time.
+//
+// The limit applies to all services of the client. Requests exceeding the limit
+// block until a previous request completes, or until their context is done.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("max concurrent requests must be greater than zero")
+		}
+
+		c.requestSlots = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// rateLimiter is a token bucket rate limiter.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64 // Tokens added to the bucket per second
+	burst  float64 // Capacity of the bucket
+	tokens float64 // Tokens currently in the bucket; negative when tokens have been reserved
+	last   time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available, or ctx is done.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	rl.mutex.Lock()
+
+	now := time.Now()
+	rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+	rl.last = now
+
+	// Reserve a token, even if it's not yet available.
+	// Waiting callers are thus served in the order they arrived.
+	rl.tokens--
+
+	var delay time.Duration
+	if rl.tokens < 0 {
+		delay = time.Duration(-rl.tokens / rl.rate * float64(time.Second))
+	}
+
+	rl.mutex.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Return the reserved token, so it can be used by other callers.
+		rl.mutex.Lock()
+		rl.tokens++
+		rl.mutex.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// acquireRequestSlot blocks until a request may be sent without exceeding
+// the maximum number of concurrent requests, or ctx is done.
+// On success, the returned function must be called to release the slot again.
+func (c Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.requestSlots <- struct{}{}:
+		return func() { <-c.requestSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	client, err := NewClient("http://localhost", WithRateLimit(20, 2))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{}`))
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		_, err = client.About.Get(context.TODO())
+		require.NoError(t, err)
+	}
+
+	// 2 requests are covered by the burst, the remaining 4 need to wait 50ms each.
+	require.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	require.Equal(t, 6, httpmock.GetTotalCallCount())
+}
+
+func TestWithRateLimit_ContextCanceled(t *testing.T) {
+	client, err := NewClient("http://localhost", WithRateLimit(0.1, 1))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{}`))
+
+	_, err = client.About.Get(context.TODO())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.About.Get(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	client, err := NewClient("http://localhost", WithMaxConcurrentRequests(2))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var inFlight, maxInFlight int32
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		func(req *http.Request) (*http.Response, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+
+			for {
+				observed := atomic.LoadInt32(&maxInFlight)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+					break
+				}
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.About.Get(context.TODO())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), maxInFlight)
+	require.Equal(t, 8, httpmock.GetTotalCallCount())
+}
+
+func TestWithMaxConcurrentRequests_Invalid(t *testing.T) {
+	_, err := NewClient("http://localhost", WithMaxConcurrentRequests(0))
+	require.Error(t, err)
+}
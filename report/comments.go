@@ -0,0 +1,35 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+// FetchAnalysisComments populates the analysis comments of findings in place.
+//
+// The findings API does not include analysis comments, so FieldComments is empty
+// for findings fetched via FindingService.GetAll unless this function is called first.
+// One request per finding is issued using AnalysisService.Get. The project of each
+// finding is taken from Finding.Component.Project, which the findings API always provides.
+func FetchAnalysisComments(ctx context.Context, client *dtrack.Client, findings []dtrack.Finding) error {
+	for i := range findings {
+		finding := &findings[i]
+		if finding.Component.Project == nil {
+			return fmt.Errorf("finding %d (%s) has no project", i, finding.Vulnerability.VulnID)
+		}
+
+		analysis, err := client.Analysis.Get(ctx, finding.Component.UUID, finding.Component.Project.UUID, finding.Vulnerability.UUID)
+		if err != nil {
+			if dtrack.IsNotFound(err) {
+				continue // Not analyzed yet
+			}
+			return fmt.Errorf("failed to fetch analysis of finding %d (%s): %w", i, finding.Vulnerability.VulnID, err)
+		}
+
+		finding.Analysis.Comments = analysis.Comments
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+func TestFetchAnalysisComments(t *testing.T) {
+	httpClient := &http.Client{}
+	httpmock.ActivateNonDefault(httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	client, err := dtrack.NewClient("http://localhost", dtrack.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	const (
+		projectUUID    = "2d16089e-6d3a-437e-b334-f27eb2cbd7f4"
+		log4jUUID      = "0d3c4a55-7d3c-4a3b-8a54-6c4a3b1a5d01"
+		commonsUUID    = "0d3c4a55-7d3c-4a3b-8a54-6c4a3b1a5d02"
+		log4ShellUUID  = "7c1d3a25-2f3e-4c7d-9a1b-3e5f6a7b8c01"
+		text4ShellUUID = "7c1d3a25-2f3e-4c7d-9a1b-3e5f6a7b8c02"
+	)
+
+	// Findings as returned by the server. Analyses don't include comments.
+	res := httpmock.NewStringResponse(http.StatusOK, fmt.Sprintf(`[
+  {
+    "component": {"uuid": "%[2]s", "group": "org.apache.logging.log4j", "name": "log4j-core", "version": "2.14.1", "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "project": "%[1]s", "projectName": "acme-app", "projectVersion": "1.0.0"},
+    "vulnerability": {"uuid": "%[4]s", "vulnId": "CVE-2021-44228", "source": "NVD", "severity": "CRITICAL", "severityRank": 0, "cvssV3BaseScore": 10.0, "cwes": [{"cweId": 502, "name": "Deserialization of Untrusted Data"}]},
+    "analysis": {"state": "EXPLOITABLE", "isSuppressed": false},
+    "attribution": {"analyzerIdentity": "INTERNAL_ANALYZER", "attributedOn": 1639137600000},
+    "matrix": "%[1]s:%[2]s:%[4]s"
+  },
+  {
+    "component": {"uuid": "%[3]s", "group": "org.apache.commons", "name": "commons-text", "version": "1.9", "project": "%[1]s", "projectName": "acme-app", "projectVersion": "1.0.0"},
+    "vulnerability": {"uuid": "%[5]s", "vulnId": "CVE-2022-42889", "source": "NVD", "severity": "CRITICAL", "severityRank": 0},
+    "analysis": {"isSuppressed": false},
+    "attribution": {"analyzerIdentity": "OSSINDEX_ANALYZER", "attributedOn": 1665964800000},
+    "matrix": "%[1]s:%[3]s:%[5]s"
+  }
+]`, projectUUID, log4jUUID, commonsUUID, log4ShellUUID, text4ShellUUID))
+	res.Header.Set("X-Total-Count", "2")
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/finding/project/"+projectUUID, httpmock.ResponderFromResponse(res))
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/analysis",
+		func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			require.Equal(t, projectUUID, query.Get("project"))
+
+			if query.Get("component") == log4jUUID && query.Get("vulnerability") == log4ShellUUID {
+				return httpmock.NewStringResponse(http.StatusOK, `{"analysisState":"EXPLOITABLE","analysisComments":[{"comment":"Reachable via JNDI lookup","commenter":"alice","timestamp":1639140000000}],"isSuppressed":false}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusNotFound, "No analysis exists."), nil
+		})
+
+	findings, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Finding], error) {
+		return client.Finding.GetAll(context.TODO(), uuid.MustParse(projectUUID), false, po)
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Empty(t, findings[0].Analysis.Comments)
+
+	err = FetchAnalysisComments(context.TODO(), client, findings)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	err = WriteCSV(&buf, findings, Options{Fields: []Field{FieldProjectName, FieldComponentName, FieldVulnID, FieldCWEs, FieldAnalysisState, FieldComments}})
+	require.NoError(t, err)
+	require.Equal(t, `projectName,componentName,vulnId,cwes,analysisState,comments
+acme-app,log4j-core,CVE-2021-44228,CWE-502,EXPLOITABLE,alice: Reachable via JNDI lookup
+acme-app,commons-text,CVE-2022-42889,,NOT_SET,
+`, buf.String())
+}
@@ -0,0 +1,20 @@
+// Package report writes findings and policy violations in formats suitable
+// for humans and spreadsheets: CSV, JSON Lines, Markdown and HTML.
+//
+// Findings are typically obtained via FindingService.GetAll:
+//
+//	findings, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Finding], error) {
+//		return client.Finding.GetAll(ctx, projectUUID, true, po)
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	// The findings API does not include analysis comments.
+//	err = report.FetchAnalysisComments(ctx, client, findings)
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = report.WriteCSV(os.Stdout, findings, report.Options{})
+package report
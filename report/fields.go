@@ -0,0 +1,238 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+// Field is a property of a finding, which is rendered as a column in tabular formats.
+// Its value is used as column header and JSON key.
+type Field string
+
+const (
+	FieldProjectUUID      Field = "projectUuid"
+	FieldProjectName      Field = "projectName"
+	FieldProjectVersion   Field = "projectVersion"
+	FieldComponentUUID    Field = "componentUuid"
+	FieldComponentGroup   Field = "componentGroup"
+	FieldComponentName    Field = "componentName"
+	FieldComponentVersion Field = "componentVersion"
+	FieldComponentPURL    Field = "componentPurl"
+	FieldVulnID           Field = "vulnId"
+	FieldSource           Field = "source"
+	FieldSeverity         Field = "severity"
+	FieldCVSSV3Score      Field = "cvssV3Score"
+	FieldCVSSV2Score      Field = "cvssV2Score"
+	FieldTitle            Field = "title"
+	FieldCWEs             Field = "cwes"
+	FieldPublished        Field = "published"
+	FieldAnalyzer         Field = "analyzer"
+	FieldReferenceURL     Field = "referenceUrl"
+	FieldAnalysisState    Field = "analysisState"
+	FieldJustification    Field = "justification"
+	FieldResponse         Field = "response"
+	FieldSuppressed       Field = "suppressed"
+	FieldComments         Field = "comments" // Requires FetchAnalysisComments for findings of FindingService.GetAll
+)
+
+// AllFields lists all fields, in the order they're rendered in by default.
+var AllFields = []Field{
+	FieldProjectUUID,
+	FieldProjectName,
+	FieldProjectVersion,
+	FieldComponentUUID,
+	FieldComponentGroup,
+	FieldComponentName,
+	FieldComponentVersion,
+	FieldComponentPURL,
+	FieldVulnID,
+	FieldSource,
+	FieldSeverity,
+	FieldCVSSV3Score,
+	FieldCVSSV2Score,
+	FieldTitle,
+	FieldCWEs,
+	FieldPublished,
+	FieldAnalyzer,
+	FieldReferenceURL,
+	FieldAnalysisState,
+	FieldJustification,
+	FieldResponse,
+	FieldSuppressed,
+	FieldComments,
+}
+
+// DefaultFields are the fields rendered when Options.Fields is empty.
+var DefaultFields = []Field{
+	FieldProjectName,
+	FieldProjectVersion,
+	FieldComponentGroup,
+	FieldComponentName,
+	FieldComponentVersion,
+	FieldComponentPURL,
+	FieldVulnID,
+	FieldSource,
+	FieldSeverity,
+	FieldCVSSV3Score,
+	FieldTitle,
+	FieldAnalysisState,
+	FieldJustification,
+	FieldResponse,
+	FieldSuppressed,
+	FieldComments,
+}
+
+// Options customizes reports.
+type Options struct {
+	// Fields selects the fields to render, and their order. Defaults to DefaultFields.
+	Fields []Field
+
+	// Title of Markdown and HTML reports. Defaults to "Findings".
+	Title string
+}
+
+func (o Options) fields() ([]Field, error) {
+	if len(o.Fields) == 0 {
+		return DefaultFields, nil
+	}
+
+	for _, field := range o.Fields {
+		if _, ok := fieldValues[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	return o.Fields, nil
+}
+
+func (o Options) title() string {
+	if o.Title == "" {
+		return "Findings"
+	}
+
+	return o.Title
+}
+
+// fieldValues extract the value of each field from a finding.
+// Values are strings, float64 (nil if unknown), bool or []string.
+var fieldValues = map[Field]func(f dtrack.Finding) interface{}{
+	FieldProjectUUID: func(f dtrack.Finding) interface{} {
+		if f.Component.Project == nil {
+			return ""
+		}
+		return f.Component.Project.UUID.String()
+	},
+	FieldProjectName: func(f dtrack.Finding) interface{} {
+		if f.Component.Project == nil {
+			return ""
+		}
+		return f.Component.Project.Name
+	},
+	FieldProjectVersion: func(f dtrack.Finding) interface{} {
+		if f.Component.Project == nil {
+			return ""
+		}
+		return f.Component.Project.Version
+	},
+	FieldComponentUUID:    func(f dtrack.Finding) interface{} { return f.Component.UUID.String() },
+	FieldComponentGroup:   func(f dtrack.Finding) interface{} { return f.Component.Group },
+	FieldComponentName:    func(f dtrack.Finding) interface{} { return f.Component.Name },
+	FieldComponentVersion: func(f dtrack.Finding) interface{} { return f.Component.Version },
+	FieldComponentPURL:    func(f dtrack.Finding) interface{} { return f.Component.PURL },
+	FieldVulnID:           func(f dtrack.Finding) interface{} { return f.Vulnerability.VulnID },
+	FieldSource:           func(f dtrack.Finding) interface{} { return f.Vulnerability.Source },
+	FieldSeverity:         func(f dtrack.Finding) interface{} { return severity(f) },
+	FieldCVSSV3Score:      func(f dtrack.Finding) interface{} { return score(f.Vulnerability.CVSSV3BaseScore) },
+	FieldCVSSV2Score:      func(f dtrack.Finding) interface{} { return score(f.Vulnerability.CVSSV2BaseScore) },
+	FieldTitle:            func(f dtrack.Finding) interface{} { return f.Vulnerability.Title },
+	FieldCWEs: func(f dtrack.Finding) interface{} {
+		cwes := make([]string, 0, len(f.Vulnerability.CWEs))
+		for _, cwe := range f.Vulnerability.CWEs {
+			cwes = append(cwes, fmt.Sprintf("CWE-%d", cwe.ID))
+		}
+		return cwes
+	},
+	FieldPublished:     func(f dtrack.Finding) interface{} { return f.Vulnerability.Published },
+	FieldAnalyzer:      func(f dtrack.Finding) interface{} { return f.Attribution.AnalyzerIdentity },
+	FieldReferenceURL:  func(f dtrack.Finding) interface{} { return f.Attribution.ReferenceURL },
+	FieldAnalysisState: func(f dtrack.Finding) interface{} { return analysisState(f) },
+	FieldJustification: func(f dtrack.Finding) interface{} { return string(f.Analysis.Justification) },
+	FieldResponse:      func(f dtrack.Finding) interface{} { return string(f.Analysis.Response) },
+	FieldSuppressed:    func(f dtrack.Finding) interface{} { return f.Analysis.Suppressed },
+	FieldComments: func(f dtrack.Finding) interface{} {
+		comments := make([]string, 0, len(f.Analysis.Comments))
+		for _, comment := range f.Analysis.Comments {
+			comments = append(comments, formatComment(comment.Commenter, comment.Comment))
+		}
+		return comments
+	},
+}
+
+// formatValue formats a field value for text-based formats.
+// Lists are joined using sep.
+func formatValue(value interface{}, sep string) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', 1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		return strings.Join(v, sep)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// severities lists all severities, most severe first.
+var severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO", "UNASSIGNED"}
+
+func severity(f dtrack.Finding) string {
+	if f.Vulnerability.Severity == "" {
+		return "UNASSIGNED"
+	}
+
+	return strings.ToUpper(f.Vulnerability.Severity)
+}
+
+// severityRank returns the rank of a severity, lower is more severe.
+func severityRank(s string) int {
+	for i, candidate := range severities {
+		if candidate == s {
+			return i
+		}
+	}
+
+	return len(severities)
+}
+
+func analysisState(f dtrack.Finding) string {
+	if f.Analysis.State == "" {
+		return string(dtrack.AnalysisStateNotSet)
+	}
+
+	return string(f.Analysis.State)
+}
+
+func formatComment(commenter, comment string) string {
+	if commenter == "" {
+		return comment
+	}
+
+	return commenter + ": " + comment
+}
+
+func score(s float64) *float64 {
+	if s <= 0 {
+		return nil
+	}
+
+	return &s
+}
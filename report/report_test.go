@@ -0,0 +1,162 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+var testFindings = []dtrack.Finding{
+	{
+		Component: dtrack.Component{Name: "commons-text", Version: "1.9", Group: "org.apache.commons", PURL: "pkg:maven/org.apache.commons/commons-text@1.9"},
+		Vulnerability: dtrack.Vulnerability{
+			VulnID:          "CVE-2022-42889",
+			Source:          "NVD",
+			Severity:        "HIGH",
+			CVSSV3BaseScore: 9.8,
+			Title:           "Text4Shell, \"RCE\"",
+		},
+		Analysis: dtrack.Analysis{
+			State:      dtrack.AnalysisStateNotAffected,
+			Suppressed: true,
+			Comments: []dtrack.AnalysisComment{
+				{Commenter: "alice", Comment: "Not reachable"},
+				{Comment: "Suppressed"},
+			},
+		},
+	},
+	{
+		Component: dtrack.Component{Name: "log4j-core", Version: "2.14.1", Group: "org.apache.logging.log4j"},
+		Vulnerability: dtrack.Vulnerability{
+			VulnID:          "CVE-2021-44228",
+			Source:          "NVD",
+			Severity:        "CRITICAL",
+			CVSSV3BaseScore: 10,
+			Title:           "Log4Shell | JNDI",
+		},
+	},
+}
+
+var testFields = []Field{FieldComponentName, FieldVulnID, FieldSeverity, FieldCVSSV3Score, FieldTitle, FieldAnalysisState, FieldSuppressed, FieldComments}
+
+func TestWriteCSV(t *testing.T) {
+	var buf strings.Builder
+	err := WriteCSV(&buf, testFindings, Options{Fields: testFields})
+	require.NoError(t, err)
+	require.Equal(t, `componentName,vulnId,severity,cvssV3Score,title,analysisState,suppressed,comments
+commons-text,CVE-2022-42889,HIGH,9.8,"Text4Shell, ""RCE""",NOT_AFFECTED,true,"alice: Not reachable
+Suppressed"
+log4j-core,CVE-2021-44228,CRITICAL,10.0,Log4Shell | JNDI,NOT_SET,false,
+`, buf.String())
+
+	err = WriteCSV(&buf, testFindings, Options{Fields: []Field{"foo"}})
+	require.EqualError(t, err, `unknown field "foo"`)
+}
+
+func TestWriteCSV_FormulaInjection(t *testing.T) {
+	findings := []dtrack.Finding{
+		{
+			Component:     dtrack.Component{Name: "=HYPERLINK(\"https://example.com\")"},
+			Vulnerability: dtrack.Vulnerability{Title: "+cmd|' /C calc'!A0"},
+			Analysis: dtrack.Analysis{
+				Comments: []dtrack.AnalysisComment{{Comment: "@SUM(1+1)"}},
+			},
+		},
+		{
+			Component:     dtrack.Component{Name: "-2+3"},
+			Vulnerability: dtrack.Vulnerability{Title: "\tfoo"},
+		},
+	}
+
+	var buf strings.Builder
+	err := WriteCSV(&buf, findings, Options{Fields: []Field{FieldComponentName, FieldTitle, FieldComments}})
+	require.NoError(t, err)
+	require.Equal(t, `componentName,title,comments
+"'=HYPERLINK(""https://example.com"")",'+cmd|' /C calc'!A0,'@SUM(1+1)
+'-2+3,'	foo,
+`, buf.String())
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf strings.Builder
+	err := WriteJSONL(&buf, testFindings, Options{Fields: []Field{FieldVulnID, FieldCVSSV2Score, FieldSuppressed, FieldComments}})
+	require.NoError(t, err)
+	require.Equal(t, `{"vulnId":"CVE-2022-42889","cvssV2Score":null,"suppressed":true,"comments":["alice: Not reachable","Suppressed"]}
+{"vulnId":"CVE-2021-44228","cvssV2Score":null,"suppressed":false,"comments":[]}
+`, buf.String())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	violations := []dtrack.PolicyViolation{
+		{
+			Type:      "LICENSE",
+			Project:   dtrack.Project{Name: "acme-app", Version: "1.0.0"},
+			Component: dtrack.Component{Name: "mysql-connector-java", Version: "8.0.28"},
+			PolicyCondition: &dtrack.PolicyCondition{
+				Policy: &dtrack.Policy{Name: "No GPL"},
+			},
+			Analysis: &dtrack.ViolationAnalysis{State: dtrack.ViolationAnalysisStateApproved},
+		},
+	}
+
+	var buf strings.Builder
+	err := WriteMarkdown(&buf, testFindings, violations, Options{Title: "acme-app", Fields: testFields})
+	require.NoError(t, err)
+	require.Equal(t, `# acme-app
+
+## Summary
+
+| Severity | Findings |
+| --- | ---: |
+| CRITICAL | 1 |
+| HIGH | 1 |
+| MEDIUM | 0 |
+| LOW | 0 |
+| INFO | 0 |
+| UNASSIGNED | 0 |
+| **Total** | **2** |
+
+| Analysis State | Findings |
+| --- | ---: |
+| NOT_AFFECTED | 1 |
+| NOT_SET | 1 |
+
+Suppressed findings: 1
+
+## Findings
+
+### org.apache.logging.log4j:log4j-core@2.14.1
+
+| vulnId | severity | cvssV3Score | title | analysisState | suppressed | comments |
+| --- | --- | --- | --- | --- | --- | --- |
+| CVE-2021-44228 | CRITICAL | 10.0 | Log4Shell \| JNDI | NOT_SET | false |  |
+
+### org.apache.commons:commons-text@1.9
+
+| vulnId | severity | cvssV3Score | title | analysisState | suppressed | comments |
+| --- | --- | --- | --- | --- | --- | --- |
+| CVE-2022-42889 | HIGH | 9.8 | Text4Shell, "RCE" | NOT_AFFECTED | true | alice: Not reachable<br>Suppressed |
+
+## Policy Violations
+
+| policy | type | project | component | analysisState | suppressed | comments |
+| --- | --- | --- | --- | --- | --- | --- |
+| No GPL | LICENSE | acme-app@1.0.0 | mysql-connector-java@8.0.28 | APPROVED | false |  |
+`, buf.String())
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf strings.Builder
+	err := WriteHTML(&buf, testFindings, nil, Options{Title: "<acme-app>"})
+	require.NoError(t, err)
+
+	html := buf.String()
+	require.Contains(t, html, "<title>&lt;acme-app&gt;</title>")
+	require.Contains(t, html, "<tr><td>CRITICAL</td><td>1</td></tr>")
+	require.Contains(t, html, "<h3>org.apache.commons:commons-text@1.9</h3>")
+	require.Contains(t, html, "<td>Text4Shell, &#34;RCE&#34;</td>")
+	require.NotContains(t, html, "Policy Violations")
+}
@@ -0,0 +1,377 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+// groupFields are the fields that identify the group of a finding in summaries.
+// They're rendered as group heading, instead of as columns.
+var groupFields = map[Field]bool{
+	FieldProjectUUID:      true,
+	FieldProjectName:      true,
+	FieldProjectVersion:   true,
+	FieldComponentUUID:    true,
+	FieldComponentGroup:   true,
+	FieldComponentName:    true,
+	FieldComponentVersion: true,
+	FieldComponentPURL:    true,
+}
+
+// analysisStates lists analysis states in the order they're rendered in summaries.
+var analysisStates = []string{
+	string(dtrack.AnalysisStateExploitable),
+	string(dtrack.AnalysisStateInTriage),
+	string(dtrack.AnalysisStateFalsePositive),
+	string(dtrack.AnalysisStateNotAffected),
+	string(dtrack.AnalysisStateResolved),
+	string(dtrack.AnalysisStateNotSet),
+}
+
+type count struct {
+	Name  string
+	Count int
+}
+
+type findingGroup struct {
+	Name     string
+	severity int
+	Rows     [][]string
+}
+
+type violationRow struct {
+	Policy     string
+	Type       string
+	Project    string
+	Component  string
+	State      string
+	Suppressed string
+	Comments   string
+}
+
+// summary is the data model shared by Markdown and HTML reports.
+type summary struct {
+	Title          string
+	Total          int
+	Suppressed     int
+	Severities     []count
+	AnalysisStates []count
+	Headers        []string
+	Groups         []findingGroup
+	Violations     []violationRow
+}
+
+func newSummary(findings []dtrack.Finding, violations []dtrack.PolicyViolation, opts Options) (s summary, err error) {
+	fields, err := opts.fields()
+	if err != nil {
+		return
+	}
+
+	var columns []Field
+	for _, field := range fields {
+		if !groupFields[field] {
+			columns = append(columns, field)
+			s.Headers = append(s.Headers, string(field))
+		}
+	}
+
+	s.Title = opts.title()
+	s.Total = len(findings)
+
+	severityCounts := make(map[string]int)
+	stateCounts := make(map[string]int)
+	groups := make(map[string]*findingGroup)
+
+	// Sort findings by severity first, so rows within each group are ordered.
+	sorted := make([]dtrack.Finding, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := severityRank(severity(sorted[i])), severityRank(severity(sorted[j]))
+		if ri != rj {
+			return ri < rj
+		}
+		return sorted[i].Vulnerability.VulnID < sorted[j].Vulnerability.VulnID
+	})
+
+	for _, finding := range sorted {
+		severityCounts[severity(finding)]++
+		stateCounts[analysisState(finding)]++
+		if finding.Analysis.Suppressed {
+			s.Suppressed++
+		}
+
+		name := groupName(finding)
+		group, ok := groups[name]
+		if !ok {
+			group = &findingGroup{Name: name, severity: severityRank(severity(finding))}
+			groups[name] = group
+		}
+
+		row := make([]string, 0, len(columns))
+		for _, field := range columns {
+			row = append(row, formatValue(fieldValues[field](finding), "\n"))
+		}
+		group.Rows = append(group.Rows, row)
+	}
+
+	for _, name := range severities {
+		s.Severities = append(s.Severities, count{Name: name, Count: severityCounts[name]})
+	}
+	for _, name := range analysisStates {
+		if stateCounts[name] > 0 {
+			s.AnalysisStates = append(s.AnalysisStates, count{Name: name, Count: stateCounts[name]})
+			delete(stateCounts, name)
+		}
+	}
+	var unknownStates []string
+	for name := range stateCounts {
+		unknownStates = append(unknownStates, name)
+	}
+	sort.Strings(unknownStates)
+	for _, name := range unknownStates {
+		s.AnalysisStates = append(s.AnalysisStates, count{Name: name, Count: stateCounts[name]})
+	}
+
+	for _, group := range groups {
+		s.Groups = append(s.Groups, *group)
+	}
+	sort.Slice(s.Groups, func(i, j int) bool {
+		if s.Groups[i].severity != s.Groups[j].severity {
+			return s.Groups[i].severity < s.Groups[j].severity
+		}
+		return s.Groups[i].Name < s.Groups[j].Name
+	})
+
+	for _, violation := range violations {
+		s.Violations = append(s.Violations, newViolationRow(violation))
+	}
+	sort.SliceStable(s.Violations, func(i, j int) bool {
+		if s.Violations[i].Policy != s.Violations[j].Policy {
+			return s.Violations[i].Policy < s.Violations[j].Policy
+		}
+		return s.Violations[i].Component < s.Violations[j].Component
+	})
+
+	return
+}
+
+// groupName returns the name of the group a finding belongs to,
+// which is the affected component and, if known, its project.
+func groupName(f dtrack.Finding) string {
+	name := componentName(f.Component.Group, f.Component.Name, f.Component.Version)
+	if f.Component.Project != nil && f.Component.Project.Name != "" {
+		name += fmt.Sprintf(" (%s)", componentName("", f.Component.Project.Name, f.Component.Project.Version))
+	}
+
+	return name
+}
+
+func componentName(group, name, version string) string {
+	if group != "" {
+		name = group + ":" + name
+	}
+	if version != "" {
+		name += "@" + version
+	}
+
+	return name
+}
+
+func newViolationRow(v dtrack.PolicyViolation) violationRow {
+	row := violationRow{
+		Type:       v.Type,
+		Project:    componentName("", v.Project.Name, v.Project.Version),
+		Component:  componentName(v.Component.Group, v.Component.Name, v.Component.Version),
+		State:      string(dtrack.ViolationAnalysisStateNotSet),
+		Suppressed: "false",
+	}
+
+	if v.PolicyCondition != nil && v.PolicyCondition.Policy != nil {
+		row.Policy = v.PolicyCondition.Policy.Name
+	}
+
+	if v.Analysis != nil {
+		if v.Analysis.State != "" {
+			row.State = string(v.Analysis.State)
+		}
+		if v.Analysis.Suppressed {
+			row.Suppressed = "true"
+		}
+
+		comments := make([]string, 0, len(v.Analysis.Comments))
+		for _, comment := range v.Analysis.Comments {
+			comments = append(comments, formatComment(comment.Commenter, comment.Comment))
+		}
+		row.Comments = strings.Join(comments, "\n")
+	}
+
+	return row
+}
+
+// WriteMarkdown writes a Markdown summary of findings and, optionally, policy violations.
+// The summary contains counts per severity and analysis state, followed by
+// the findings grouped by component, ordered by their highest severity.
+func WriteMarkdown(w io.Writer, findings []dtrack.Finding, violations []dtrack.PolicyViolation, opts Options) error {
+	s, err := newSummary(findings, violations, opts)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", markdownEscape(s.Title))
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Severity | Findings |\n| --- | ---: |\n")
+	for _, c := range s.Severities {
+		fmt.Fprintf(&sb, "| %s | %d |\n", c.Name, c.Count)
+	}
+	fmt.Fprintf(&sb, "| **Total** | **%d** |\n\n", s.Total)
+
+	if len(s.AnalysisStates) > 0 {
+		sb.WriteString("| Analysis State | Findings |\n| --- | ---: |\n")
+		for _, c := range s.AnalysisStates {
+			fmt.Fprintf(&sb, "| %s | %d |\n", markdownEscape(c.Name), c.Count)
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "Suppressed findings: %d\n\n", s.Suppressed)
+
+	sb.WriteString("## Findings\n\n")
+	if len(s.Groups) == 0 {
+		sb.WriteString("No findings.\n\n")
+	}
+	for _, group := range s.Groups {
+		fmt.Fprintf(&sb, "### %s\n\n", markdownEscape(group.Name))
+		writeMarkdownTable(&sb, s.Headers, group.Rows)
+	}
+
+	if len(s.Violations) > 0 {
+		sb.WriteString("## Policy Violations\n\n")
+		rows := make([][]string, 0, len(s.Violations))
+		for _, v := range s.Violations {
+			rows = append(rows, []string{v.Policy, v.Type, v.Project, v.Component, v.State, v.Suppressed, v.Comments})
+		}
+		writeMarkdownTable(&sb, violationHeaders, rows)
+	}
+
+	_, err = io.WriteString(w, strings.TrimSuffix(sb.String(), "\n"))
+	return err
+}
+
+var violationHeaders = []string{"policy", "type", "project", "component", "analysisState", "suppressed", "comments"}
+
+func writeMarkdownTable(sb *strings.Builder, headers []string, rows [][]string) {
+	sb.WriteString("|")
+	for _, header := range headers {
+		fmt.Fprintf(sb, " %s |", markdownEscape(header))
+	}
+	sb.WriteString("\n|")
+	for range headers {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+
+	for _, row := range rows {
+		sb.WriteString("|")
+		for _, cell := range row {
+			fmt.Fprintf(sb, " %s |", markdownEscape(cell))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+var markdownReplacer = strings.NewReplacer(
+	"\\", "\\\\",
+	"|", "\\|",
+	"<", "&lt;",
+	">", "&gt;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// markdownEscape escapes s for use in headings and table cells.
+// Line breaks are preserved as <br>.
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+// WriteHTML writes a standalone HTML document with the same content as WriteMarkdown.
+func WriteHTML(w io.Writer, findings []dtrack.Finding, violations []dtrack.PolicyViolation, opts Options) error {
+	s, err := newSummary(findings, violations, opts)
+	if err != nil {
+		return err
+	}
+
+	return htmlTemplate.Execute(w, struct {
+		summary
+		ViolationHeaders []string
+	}{
+		summary:          s,
+		ViolationHeaders: violationHeaders,
+	})
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; white-space: pre-line; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<h2>Summary</h2>
+<table>
+<tr><th>Severity</th><th>Findings</th></tr>
+{{- range .Severities }}
+<tr><td>{{ .Name }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+<tr><th>Total</th><th>{{ .Total }}</th></tr>
+</table>
+{{- if .AnalysisStates }}
+<table>
+<tr><th>Analysis State</th><th>Findings</th></tr>
+{{- range .AnalysisStates }}
+<tr><td>{{ .Name }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+<p>Suppressed findings: {{ .Suppressed }}</p>
+<h2>Findings</h2>
+{{- if not .Groups }}
+<p>No findings.</p>
+{{- end }}
+{{- $headers := .Headers }}
+{{- range .Groups }}
+<h3>{{ .Name }}</h3>
+<table>
+<tr>{{ range $headers }}<th>{{ . }}</th>{{ end }}</tr>
+{{- range .Rows }}
+<tr>{{ range . }}<td>{{ . }}</td>{{ end }}</tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Violations }}
+<h2>Policy Violations</h2>
+<table>
+<tr>{{ range .ViolationHeaders }}<th>{{ . }}</th>{{ end }}</tr>
+{{- range .Violations }}
+<tr><td>{{ .Policy }}</td><td>{{ .Type }}</td><td>{{ .Project }}</td><td>{{ .Component }}</td><td>{{ .State }}</td><td>{{ .Suppressed }}</td><td>{{ .Comments }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
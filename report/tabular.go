@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+// WriteCSV writes findings as CSV, with a header row followed by one row per finding.
+// Lists, like comments, are joined by newlines.
+//
+// To prevent formula injection when the file is opened in a spreadsheet application,
+// cells starting with =, +, -, @, tab or carriage return are prefixed with a single quote.
+func WriteCSV(w io.Writer, findings []dtrack.Finding, opts Options) error {
+	fields, err := opts.fields()
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+
+	header := make([]string, 0, len(fields))
+	for _, field := range fields {
+		header = append(header, string(field))
+	}
+	if err = cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, finding := range findings {
+		record := make([]string, 0, len(fields))
+		for _, field := range fields {
+			record = append(record, csvEscape(formatValue(fieldValues[field](finding), "\n")))
+		}
+		if err = cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSONL writes findings as JSON Lines, with one object per finding.
+// Keys of each object appear in the order of the selected fields.
+func WriteJSONL(w io.Writer, findings []dtrack.Finding, opts Options) error {
+	fields, err := opts.fields()
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	for _, finding := range findings {
+		bw.WriteByte('{')
+		for i, field := range fields {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+
+			key, err := json.Marshal(string(field))
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(fieldValues[field](finding))
+			if err != nil {
+				return err
+			}
+
+			bw.Write(key)
+			bw.WriteByte(':')
+			bw.Write(value)
+		}
+		bw.WriteString("}\n")
+	}
+
+	return bw.Flush()
+}
+
+// csvEscape neutralizes cells that spreadsheet applications would interpret as formula.
+func csvEscape(cell string) string {
+	if cell == "" {
+		return cell
+	}
+
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	default:
+		return cell
+	}
+}
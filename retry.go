@@ -0,0 +1,196 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryMaxRetries = 3
+	DefaultRetryMinBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
+// RetryPolicy describes how failed requests are retried.
+//
+// Only idempotent requests (GET, HEAD, DELETE) are retried. A request is retried
+// when it failed due to a connection error, or when the server responded with
+// status 429 (Too Many Requests) or any 5xx status.
+//
+// Dependency-Track uses PUT to create resources, so retrying a PUT request after
+// the server processed it may create duplicates. PUT requests are thus only retried
+// when the server responded with status 429, or with status 503 (Service Unavailable)
+// and a Retry-After header, both of which indicate that it rejected the request
+// without processing it.
+type RetryPolicy struct {
+	MaxRetries int           // Maximum number of retries, not counting the initial attempt
+	MinBackoff time.Duration // Backoff before the first retry
+	MaxBackoff time.Duration // Upper bound for the backoff between retries
+}
+
+// WithRetryPolicy enables automatic retries of failed requests.
+// Zero values in the given policy are replaced with their respective defaults.
+//
+// Backoff grows exponentially with every attempt and is jittered.
+// A Retry-After header sent by the server takes precedence over the computed backoff.
+// If it asks for a longer delay than MaxBackoff, the request is not retried.
+// No retry is attempted when the backoff would exceed the deadline of the request context.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxRetries <= 0 {
+			policy.MaxRetries = DefaultRetryMaxRetries
+		}
+		if policy.MinBackoff <= 0 {
+			policy.MinBackoff = DefaultRetryMinBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = DefaultRetryMaxBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			policy.MaxBackoff = policy.MinBackoff
+		}
+
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// shouldRetry determines whether a request should be retried,
+// given the outcome of its most recent attempt.
+func (rp RetryPolicy) shouldRetry(req *http.Request, res *http.Response, err error, attempt int) bool {
+	if attempt >= rp.MaxRetries {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+	case http.MethodPut:
+		if err != nil || !isRejectedUnprocessed(res) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false // Body can't be rewound
+	}
+
+	if err != nil {
+		// Errors caused by the request context are final.
+		return req.Context().Err() == nil &&
+			!errors.Is(err, context.Canceled) &&
+			!errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+}
+
+// isRejectedUnprocessed determines whether res indicates that the server
+// rejected a request without processing it.
+func isRejectedUnprocessed(res *http.Response) bool {
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return res.StatusCode == http.StatusServiceUnavailable && res.Header.Get("Retry-After") != ""
+}
+
+// backoff determines how long to wait before the next attempt.
+// It returns false when the server asked for a delay longer than MaxBackoff.
+func (rp RetryPolicy) backoff(res *http.Response, attempt int) (time.Duration, bool) {
+	if res != nil {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= rp.MaxBackoff
+		}
+	}
+
+	backoff := rp.MaxBackoff
+	if attempt < 32 {
+		if exp := rp.MinBackoff << attempt; exp > 0 && exp < rp.MaxBackoff {
+			backoff = exp
+		}
+	}
+
+	// Equal jitter: wait at least half of the backoff, and a random share of the remainder.
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// parseRetryAfter parses the value of a Retry-After header,
+// which may either be a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// fitsDeadline determines whether waiting for backoff before retrying
+// would still leave time for another attempt within the deadline of ctx.
+func fitsDeadline(ctx context.Context, backoff time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+		return false
+	}
+
+	return true
+}
+
+// sleepContext blocks for the given duration, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindBody resets the body of req so it can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
+}
+
+// drainBody discards the remaining content of a response body and closes it,
+// allowing the underlying connection to be reused.
+func drainBody(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096))
+	_ = body.Close()
+}
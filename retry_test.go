@@ -0,0 +1,178 @@
+package dtrack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetryPolicy(t *testing.T) {
+	newClientWithMaxBackoff := func(t *testing.T, maxBackoff time.Duration) *Client {
+		client, err := NewClient("http://localhost", WithRetryPolicy(RetryPolicy{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: maxBackoff,
+		}))
+		require.NoError(t, err)
+
+		httpmock.ActivateNonDefault(client.httpClient)
+		t.Cleanup(httpmock.DeactivateAndReset)
+
+		return client
+	}
+	newClient := func(t *testing.T) *Client {
+		return newClientWithMaxBackoff(t, 5*time.Millisecond)
+	}
+
+	t.Run("RetryOnServerError", func(t *testing.T) {
+		client := newClient(t)
+
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.ResponderFromMultipleResponses([]*http.Response{
+				httpmock.NewStringResponse(http.StatusBadGateway, ""),
+				httpmock.NewStringResponse(http.StatusServiceUnavailable, ""),
+				httpmock.NewStringResponse(http.StatusOK, `{"version":"4.7.0"}`),
+			}))
+
+		about, err := client.About.Get(context.TODO())
+		require.NoError(t, err)
+		require.Equal(t, "4.7.0", about.Version)
+		require.Equal(t, 3, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("GiveUpAfterMaxRetries", func(t *testing.T) {
+		client := newClient(t)
+
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, "unavailable"))
+
+		_, err := client.About.Get(context.TODO())
+		require.Error(t, err)
+		require.Equal(t, 3, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("NoRetryOnClientError", func(t *testing.T) {
+		client := newClient(t)
+
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+		_, err := client.About.Get(context.TODO())
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("NoRetryForNonIdempotentMethod", func(t *testing.T) {
+		client := newClient(t)
+
+		httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/project",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+		_, err := client.Project.Update(context.TODO(), Project{Name: "acme-app"})
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("NoRetryForPUTOnServerError", func(t *testing.T) {
+		client := newClient(t)
+
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project",
+			httpmock.NewStringResponder(http.StatusBadGateway, ""))
+
+		_, err := client.Project.Create(context.TODO(), Project{Name: "acme-app"})
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("RetryPUTWhenRejected", func(t *testing.T) {
+		client := newClient(t)
+
+		unavailable := httpmock.NewStringResponse(http.StatusServiceUnavailable, "")
+		unavailable.Header.Set("Retry-After", "0")
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project",
+			httpmock.ResponderFromMultipleResponses([]*http.Response{
+				httpmock.NewStringResponse(http.StatusTooManyRequests, ""),
+				unavailable,
+				httpmock.NewStringResponse(http.StatusCreated, `{"name":"acme-app"}`),
+			}))
+
+		project, err := client.Project.Create(context.TODO(), Project{Name: "acme-app"})
+		require.NoError(t, err)
+		require.Equal(t, "acme-app", project.Name)
+		require.Equal(t, 3, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("RewindBody", func(t *testing.T) {
+		client := newClient(t)
+
+		var bodies []string
+		httpmock.RegisterResponder(http.MethodDelete, "http://localhost/api/v1/tag",
+			func(req *http.Request) (*http.Response, error) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				bodies = append(bodies, string(body))
+
+				if len(bodies) == 1 {
+					return httpmock.NewStringResponse(http.StatusBadGateway, ""), nil
+				}
+				return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+			})
+
+		req, err := client.newRequest(context.TODO(), http.MethodDelete, "/api/v1/tag", withBody([]string{"foo"}))
+		require.NoError(t, err)
+
+		_, err = client.doRequest(req, nil)
+		require.NoError(t, err)
+		require.Len(t, bodies, 2)
+		require.Equal(t, bodies[0], bodies[1])
+		require.JSONEq(t, `["foo"]`, bodies[0])
+	})
+
+	t.Run("NoRetryWhenRetryAfterExceedsMaxBackoff", func(t *testing.T) {
+		client := newClient(t)
+
+		res := httpmock.NewStringResponse(http.StatusServiceUnavailable, "")
+		res.Header.Set("Retry-After", "86400")
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version", httpmock.ResponderFromResponse(res))
+
+		_, err := client.About.Get(context.TODO())
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("RespectContextDeadline", func(t *testing.T) {
+		client := newClientWithMaxBackoff(t, time.Hour)
+
+		res := httpmock.NewStringResponse(http.StatusServiceUnavailable, "")
+		res.Header.Set("Retry-After", "60")
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version", httpmock.ResponderFromResponse(res))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := client.About.Get(ctx)
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("120")
+	require.True(t, ok)
+	require.Equal(t, 120*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.InDelta(t, time.Hour, delay, float64(2*time.Second))
+
+	_, ok = parseRetryAfter("")
+	require.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
@@ -0,0 +1,287 @@
+package dtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is a Static Analysis Results Interchange Format (SARIF) 2.1.0 document.
+// Only the parts of the format needed to represent findings are modeled,
+// so it is not suitable for round-tripping arbitrary SARIF documents.
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema,omitempty"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	FullName       string      `json:"fullName,omitempty"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name,omitempty"`
+	ShortDescription *SARIFMessage          `json:"shortDescription,omitempty"`
+	FullDescription  *SARIFMessage          `json:"fullDescription,omitempty"`
+	Help             *SARIFMessage          `json:"help,omitempty"`
+	HelpURI          string                 `json:"helpUri,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  *int                   `json:"ruleIndex,omitempty"`
+	Level      string                 `json:"level"`
+	Message    SARIFMessage           `json:"message"`
+	Locations  []SARIFLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFLogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// minVersionSARIF is the minimum version of Dependency-Track that can export findings as SARIF.
+var minVersionSARIF = Version{Major: 4, Minor: 10}
+
+// ExportSARIF exports the unsuppressed findings of a project as SARIF, and writes it to w.
+// It returns the number of bytes written.
+//
+// When the server supports it, the document generated by the server is written as-is.
+// Otherwise, it is built locally from the project's findings, using NewSARIFLog.
+func (f FindingService) ExportSARIF(ctx context.Context, projectUUID uuid.UUID, w io.Writer) (written int64, err error) {
+	serverVersion, err := f.client.ServerVersion(ctx)
+	if err != nil {
+		return
+	}
+
+	if !serverVersion.AtLeast(minVersionSARIF) {
+		var findings []Finding
+		findings, err = FetchAll(func(po PageOptions) (Page[Finding], error) {
+			return f.GetAll(ctx, projectUUID, false, po)
+		})
+		if err != nil {
+			return
+		}
+
+		log := NewSARIFLog(findings)
+		log.Runs[0].Tool.Driver.Version = serverVersion.String()
+
+		var content []byte
+		content, err = json.Marshal(log)
+		if err != nil {
+			return
+		}
+
+		var n int
+		n, err = w.Write(content)
+		written = int64(n)
+		return
+	}
+
+	req, err := f.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/finding/project/%s", projectUUID))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Accept", "application/sarif+json")
+
+	rw := responseWriter{w: w}
+	_, err = f.client.doRequest(req, &rw)
+	written = rw.written
+	return
+}
+
+// NewSARIFLog builds a SARIF document from findings.
+//
+// Each vulnerability becomes a rule, and each finding a result referencing
+// the affected component by its Package URL, or its name if it has none.
+// Like in documents generated by the server, components are referenced as logical locations.
+func NewSARIFLog(findings []Finding) SARIFLog {
+	driver := SARIFDriver{
+		Name:           "OWASP Dependency-Track",
+		FullName:       "OWASP Dependency-Track - Component Analysis Platform",
+		InformationURI: "https://dependencytrack.org/",
+		Rules:          []SARIFRule{},
+	}
+
+	ruleIndices := make(map[string]int)
+	results := make([]SARIFResult, 0, len(findings))
+
+	for _, finding := range findings {
+		vuln := finding.Vulnerability
+
+		ruleIndex, ok := ruleIndices[vuln.VulnID]
+		if !ok {
+			ruleIndex = len(driver.Rules)
+			ruleIndices[vuln.VulnID] = ruleIndex
+			driver.Rules = append(driver.Rules, newSARIFRule(finding))
+		} else if driver.Rules[ruleIndex].HelpURI == "" {
+			driver.Rules[ruleIndex].HelpURI = finding.Attribution.ReferenceURL
+		}
+
+		results = append(results, newSARIFResult(finding, ruleIndex))
+	}
+
+	return SARIFLog{
+		Version: SARIFVersion,
+		Schema:  SARIFSchema,
+		Runs: []SARIFRun{
+			{
+				Tool:    SARIFTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+}
+
+func newSARIFRule(finding Finding) SARIFRule {
+	vuln := finding.Vulnerability
+
+	rule := SARIFRule{
+		ID:      vuln.VulnID,
+		Name:    vuln.VulnID,
+		HelpURI: finding.Attribution.ReferenceURL,
+		Properties: map[string]interface{}{
+			"source":   vuln.Source,
+			"severity": vuln.Severity,
+			"tags":     []string{"security", "vulnerability"},
+		},
+	}
+
+	if vuln.Title != "" {
+		rule.ShortDescription = &SARIFMessage{Text: vuln.Title}
+	} else {
+		rule.ShortDescription = &SARIFMessage{Text: vuln.VulnID}
+	}
+	if vuln.Description != "" {
+		rule.FullDescription = &SARIFMessage{Text: vuln.Description}
+	}
+	if vuln.Recommendation != "" {
+		rule.Help = &SARIFMessage{Text: vuln.Recommendation}
+	}
+
+	// Used by code scanning platforms like GitHub to rank results.
+	if score := sarifSecurityScore(vuln); score > 0 {
+		rule.Properties["security-severity"] = strconv.FormatFloat(score, 'f', 1, 64)
+	}
+
+	var cwes []string
+	for _, cwe := range vuln.CWEs {
+		cwes = append(cwes, fmt.Sprintf("CWE-%d", cwe.ID))
+	}
+	if len(cwes) > 0 {
+		rule.Properties["cwes"] = cwes
+	}
+
+	return rule
+}
+
+func newSARIFResult(finding Finding, ruleIndex int) SARIFResult {
+	component := finding.Component
+
+	name := component.Name
+	if component.Group != "" {
+		name = component.Group + ":" + name
+	}
+	if component.Version != "" {
+		name += "@" + component.Version
+	}
+
+	location := component.PURL
+	if location == "" {
+		location = name
+	}
+
+	message := fmt.Sprintf("%s is affected by %s", name, finding.Vulnerability.VulnID)
+	if finding.Vulnerability.Title != "" {
+		message += ": " + finding.Vulnerability.Title
+	}
+
+	result := SARIFResult{
+		RuleID:    finding.Vulnerability.VulnID,
+		RuleIndex: &ruleIndex,
+		Level:     sarifLevel(finding.Vulnerability.Severity),
+		Message:   SARIFMessage{Text: message},
+		Locations: []SARIFLocation{
+			{LogicalLocations: []SARIFLogicalLocation{{FullyQualifiedName: location}}},
+		},
+		Properties: map[string]interface{}{
+			"componentUuid": component.UUID.String(),
+			"name":          component.Name,
+			"group":         component.Group,
+			"version":       component.Version,
+		},
+	}
+
+	if finding.Analysis.State != "" {
+		result.Properties["analysisState"] = string(finding.Analysis.State)
+	}
+
+	return result
+}
+
+// sarifLevel maps a vulnerability severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifSecurityScore returns the CVSS score of a vulnerability, preferring CVSSv3.
+func sarifSecurityScore(vuln Vulnerability) float64 {
+	if vuln.CVSSV3BaseScore > 0 {
+		return vuln.CVSSV3BaseScore
+	}
+
+	return vuln.CVSSV2BaseScore
+}
@@ -0,0 +1,191 @@
+package dtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSARIFLog(t *testing.T) {
+	findings := []Finding{
+		{
+			Attribution: FindingAttribution{ReferenceURL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+			Component:   Component{Group: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+			Vulnerability: Vulnerability{
+				VulnID:          "CVE-2021-44228",
+				Source:          "NVD",
+				Title:           "Log4Shell",
+				Severity:        "CRITICAL",
+				CVSSV3BaseScore: 10,
+				CWEs:            []CWE{{ID: 502}},
+			},
+			Analysis: Analysis{State: AnalysisStateExploitable},
+		},
+		{
+			Component:     Component{Name: "log4j-api", Version: "2.14.1"},
+			Vulnerability: Vulnerability{VulnID: "CVE-2021-45046", Severity: "MEDIUM"},
+		},
+		{
+			Component:     Component{Name: "other", Version: "1.0"},
+			Vulnerability: Vulnerability{VulnID: "CVE-2021-44228", Severity: "CRITICAL"},
+		},
+	}
+
+	log := NewSARIFLog(findings)
+	require.Equal(t, SARIFVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+
+	rules := log.Runs[0].Tool.Driver.Rules
+	require.Len(t, rules, 2)
+	require.Equal(t, "CVE-2021-44228", rules[0].ID)
+	require.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2021-44228", rules[0].HelpURI)
+	require.Equal(t, &SARIFMessage{Text: "Log4Shell"}, rules[0].ShortDescription)
+	require.Equal(t, "10.0", rules[0].Properties["security-severity"])
+	require.Equal(t, []string{"CWE-502"}, rules[0].Properties["cwes"])
+	require.Equal(t, "CVE-2021-45046", rules[1].ID)
+
+	results := log.Runs[0].Results
+	require.Len(t, results, 3)
+	require.Equal(t, "error", results[0].Level)
+	require.Equal(t, 0, *results[0].RuleIndex)
+	require.Equal(t, "org.apache.logging.log4j:log4j-core@2.14.1 is affected by CVE-2021-44228: Log4Shell", results[0].Message.Text)
+	require.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	require.Equal(t, "EXPLOITABLE", results[0].Properties["analysisState"])
+	require.Equal(t, "warning", results[1].Level)
+	require.Equal(t, 1, *results[1].RuleIndex)
+	require.Equal(t, "log4j-api@2.14.1", results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	require.Equal(t, 0, *results[2].RuleIndex)
+}
+
+// serverSARIF resembles a document exported by Dependency-Track 4.10.
+const serverSARIF = `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "OWASP Dependency-Track",
+          "fullName": "OWASP Dependency-Track - Component Analysis Platform",
+          "version": "4.10.0",
+          "informationUri": "https://dependencytrack.org/",
+          "rules": [
+            {
+              "id": "CVE-2021-44228",
+              "name": "ImproperInputValidation",
+              "shortDescription": {"text": "CVE-2021-44228"},
+              "fullDescription": {"text": "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints."},
+              "properties": {"tags": ["security", "CWE-20"]}
+            },
+            {
+              "id": "CVE-2021-45046",
+              "name": "DeserializationOfUntrustedData",
+              "shortDescription": {"text": "CVE-2021-45046"},
+              "fullDescription": {"text": "The fix to address CVE-2021-44228 was incomplete."},
+              "properties": {"tags": ["security", "CWE-502"]}
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "CVE-2021-44228",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {"text": "log4j-core@2.14.1 is affected by CVE-2021-44228"},
+          "locations": [{"logicalLocations": [{"fullyQualifiedName": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]}],
+          "properties": {"name": "log4j-core", "group": "org.apache.logging.log4j", "version": "2.14.1", "source": "NVD", "cvssV3BaseScore": "10.0"}
+        },
+        {
+          "ruleId": "CVE-2021-45046",
+          "level": "error",
+          "message": {"text": "log4j-api@2.14.1 is affected by CVE-2021-45046"},
+          "locations": [{"logicalLocations": [{"fullyQualifiedName": "pkg:maven/org.apache.logging.log4j/log4j-api@2.14.1"}]}],
+          "properties": {"name": "log4j-api", "group": "org.apache.logging.log4j", "version": "2.14.1", "source": "NVD", "cvssV3BaseScore": "9.0"}
+        }
+      ]
+    }
+  ]
+}`
+
+func TestFindingService_ExportSARIF(t *testing.T) {
+	projectUUID := uuid.MustParse("2d16089e-6d3a-437e-b334-f27eb2cbd7f4")
+	findingsURL := fmt.Sprintf("http://localhost/api/v1/finding/project/%s", projectUUID)
+
+	t.Run("Server", func(t *testing.T) {
+		client, err := NewClient("http://localhost", WithServerVersion("4.10.0"))
+		require.NoError(t, err)
+
+		httpmock.ActivateNonDefault(client.httpClient)
+		defer httpmock.DeactivateAndReset()
+
+		httpmock.RegisterResponder(http.MethodGet, findingsURL,
+			func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, "application/sarif+json", req.Header.Get("Accept"))
+				return httpmock.NewStringResponse(http.StatusOK, serverSARIF), nil
+			})
+
+		var buf strings.Builder
+		written, err := client.Finding.ExportSARIF(context.TODO(), projectUUID, &buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(serverSARIF)), written)
+		require.Equal(t, serverSARIF, buf.String())
+
+		// Documents of the server must survive a round-trip through SARIFLog.
+		var log SARIFLog
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &log))
+		require.Nil(t, log.Runs[0].Results[1].RuleIndex)
+		require.Equal(t, []SARIFLogicalLocation{{FullyQualifiedName: "pkg:maven/org.apache.logging.log4j/log4j-api@2.14.1"}}, log.Runs[0].Results[1].Locations[0].LogicalLocations)
+
+		roundTripped, err := json.Marshal(log)
+		require.NoError(t, err)
+		require.JSONEq(t, serverSARIF, string(roundTripped))
+	})
+
+	t.Run("Local", func(t *testing.T) {
+		client, err := NewClient("http://localhost", WithServerVersion("4.9.0"))
+		require.NoError(t, err)
+
+		httpmock.ActivateNonDefault(client.httpClient)
+		defer httpmock.DeactivateAndReset()
+
+		httpmock.RegisterResponder(http.MethodGet, findingsURL,
+			func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, "application/json", req.Header.Get("Accept"))
+				require.Equal(t, "false", req.URL.Query().Get("suppressed"))
+
+				res := httpmock.NewStringResponse(http.StatusOK, `[{"component":{"name":"log4j-core","purl":"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},"vulnerability":{"vulnId":"CVE-2021-44228","severity":"HIGH"}}]`)
+				res.Header.Set("X-Total-Count", "1")
+				return res, nil
+			})
+
+		var buf strings.Builder
+		written, err := client.Finding.ExportSARIF(context.TODO(), projectUUID, &buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(buf.Len()), written)
+
+		var log SARIFLog
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &log))
+		require.Equal(t, SARIFSchema, log.Schema)
+		require.Equal(t, "4.9.0", log.Runs[0].Tool.Driver.Version)
+		require.Len(t, log.Runs[0].Results, 1)
+		require.Equal(t, "error", log.Runs[0].Results[0].Level)
+
+		// Locations must be shaped like those of documents generated by the server.
+		var serverLog SARIFLog
+		require.NoError(t, json.Unmarshal([]byte(serverSARIF), &serverLog))
+		serverLocations, err := json.Marshal(serverLog.Runs[0].Results[0].Locations)
+		require.NoError(t, err)
+		localLocations, err := json.Marshal(log.Runs[0].Results[0].Locations)
+		require.NoError(t, err)
+		require.JSONEq(t, `[{"logicalLocations":[{"fullyQualifiedName":"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]}]`, string(serverLocations))
+		require.JSONEq(t, `[{"logicalLocations":[{"fullyQualifiedName":"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]}]`, string(localLocations))
+	})
+}
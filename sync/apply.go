@@ -0,0 +1,155 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+// Apply executes the actions of plan in order. It stops at the first failing action.
+func Apply(ctx context.Context, client *dtrack.Client, plan Plan) error {
+	projectUUIDs := make(map[ProjectRef]uuid.UUID, len(plan.projectUUIDs))
+	for ref, projectUUID := range plan.projectUUIDs {
+		projectUUIDs[ref] = projectUUID
+	}
+
+	resolve := func(ref ProjectRef) (uuid.UUID, error) {
+		if projectUUID, ok := projectUUIDs[ref]; ok {
+			return projectUUID, nil
+		}
+
+		return uuid.Nil, fmt.Errorf("project %s does not exist", ref)
+	}
+
+	for _, action := range plan.Actions {
+		projectUUID := action.ProjectUUID
+		if projectUUID == uuid.Nil && action.Type != ActionCreateProject {
+			var err error
+			projectUUID, err = resolve(action.Project)
+			if err != nil {
+				return fmt.Errorf("failed to apply %s: %w", action.Type, err)
+			}
+		}
+
+		var err error
+		switch action.Type {
+		case ActionCreateProject:
+			var project dtrack.Project
+			project, err = toProject(action.Desired, dtrack.Project{Active: true}, resolve)
+			if err != nil {
+				break
+			}
+
+			project, err = client.Project.Create(ctx, project)
+			if err == nil {
+				projectUUIDs[action.Project] = project.UUID
+			}
+		case ActionPatchProject:
+			var current dtrack.Project
+			current, err = client.Project.Get(ctx, projectUUID)
+			if err != nil {
+				break
+			}
+
+			var project dtrack.Project
+			project, err = toProject(action.Desired, current, resolve)
+			if err != nil {
+				break
+			}
+
+			_, err = client.Project.Patch(ctx, projectUUID, project)
+		case ActionDeleteProject:
+			err = client.Project.Delete(ctx, projectUUID)
+		case ActionCreateProperty:
+			_, err = client.ProjectProperty.Create(ctx, projectUUID, action.Property)
+		case ActionUpdateProperty:
+			_, err = client.ProjectProperty.Update(ctx, projectUUID, action.Property)
+		case ActionDeleteProperty:
+			err = client.ProjectProperty.Delete(ctx, projectUUID, action.Property.Group, action.Property.Name)
+		default:
+			err = fmt.Errorf("unknown action type")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply %s for %s: %w", action.Type, action.Project, err)
+		}
+	}
+
+	return nil
+}
+
+// toProject applies the managed fields of desired to base.
+func toProject(desired Project, base dtrack.Project, resolve func(ProjectRef) (uuid.UUID, error)) (project dtrack.Project, err error) {
+	// Only send fields that are managed, and those that are always serialized.
+	project = dtrack.Project{
+		Name:    desired.Name,
+		Version: desired.Version,
+		Active:  base.Active,
+		Tags:    base.Tags,
+	}
+
+	if desired.Classifier != "" {
+		project.Classifier = desired.Classifier
+	}
+	if desired.Description != "" {
+		project.Description = desired.Description
+	}
+	if desired.Active != nil {
+		project.Active = *desired.Active
+	}
+
+	if len(desired.Tags) > 0 {
+		project.Tags = make([]dtrack.Tag, 0, len(desired.Tags))
+		for _, tag := range desired.Tags {
+			project.Tags = append(project.Tags, dtrack.Tag{Name: tag})
+		}
+	}
+
+	if desired.Parent != nil {
+		var parentUUID uuid.UUID
+		parentUUID, err = resolve(*desired.Parent)
+		if err != nil {
+			return
+		}
+
+		project.Parent = &dtrack.ParentRef{UUID: parentUUID}
+	}
+
+	return
+}
+
+// Options customizes Reconcile.
+type Options struct {
+	PlanOptions
+
+	// DryRun prevents the plan from being applied.
+	DryRun bool
+
+	// Output receives the plan in human-readable form, if not nil.
+	Output io.Writer
+}
+
+// Reconcile makes a plan to reconcile the server with the desired state, writes it to
+// opts.Output, and applies it unless opts.DryRun is set.
+func Reconcile(ctx context.Context, client *dtrack.Client, desired State, opts Options) (plan Plan, err error) {
+	plan, err = NewPlan(ctx, client, desired, opts.PlanOptions)
+	if err != nil {
+		return
+	}
+
+	if opts.Output != nil {
+		if _, err = fmt.Fprintln(opts.Output, plan.String()); err != nil {
+			return
+		}
+	}
+
+	if opts.DryRun || plan.IsEmpty() {
+		return
+	}
+
+	err = Apply(ctx, client, plan)
+	return
+}
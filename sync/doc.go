@@ -0,0 +1,22 @@
+// Package sync reconciles projects in Dependency-Track with a desired state,
+// which is typically kept in version control.
+//
+// The desired state is described in YAML:
+//
+//	projects:
+//	  - name: acme
+//	    classifier: APPLICATION
+//	    tags: [product-line]
+//	  - name: acme-app
+//	    version: 1.0.0
+//	    parent:
+//	      name: acme
+//	    properties:
+//	      - group: ownership
+//	        name: team
+//	        value: team-a
+//
+// Projects are identified by their name and version. NewPlan diffs the desired state
+// against the projects on the server and produces a Plan, which can be printed
+// for a dry-run, and applied using Apply.
+package sync
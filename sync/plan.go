@@ -0,0 +1,401 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+type ActionType string
+
+const (
+	ActionCreateProject  ActionType = "create-project"
+	ActionPatchProject   ActionType = "patch-project"
+	ActionDeleteProject  ActionType = "delete-project"
+	ActionCreateProperty ActionType = "create-property"
+	ActionUpdateProperty ActionType = "update-property"
+	ActionDeleteProperty ActionType = "delete-property"
+)
+
+// Action is a single change to be made on the server.
+type Action struct {
+	Type        ActionType
+	Project     ProjectRef
+	ProjectUUID uuid.UUID              // Nil for projects that are created by the plan
+	Desired     Project                // Desired state of the project, for ActionCreateProject and ActionPatchProject
+	Changes     []Change               // Changed fields, for ActionPatchProject and ActionUpdateProperty
+	Property    dtrack.ProjectProperty // Desired property, for property actions
+}
+
+// Change describes the change of a single field.
+type Change struct {
+	Field string
+	From  string
+	To    string
+}
+
+func (a Action) String() string {
+	var sb strings.Builder
+
+	switch a.Type {
+	case ActionCreateProject:
+		fmt.Fprintf(&sb, "+ project %s", a.Project)
+	case ActionPatchProject:
+		fmt.Fprintf(&sb, "~ project %s", a.Project)
+	case ActionDeleteProject:
+		fmt.Fprintf(&sb, "- project %s", a.Project)
+	case ActionCreateProperty:
+		fmt.Fprintf(&sb, "+ property %s %s/%s = %q", a.Project, a.Property.Group, a.Property.Name, a.Property.Value)
+	case ActionUpdateProperty:
+		fmt.Fprintf(&sb, "~ property %s %s/%s", a.Project, a.Property.Group, a.Property.Name)
+	case ActionDeleteProperty:
+		fmt.Fprintf(&sb, "- property %s %s/%s", a.Project, a.Property.Group, a.Property.Name)
+	default:
+		fmt.Fprintf(&sb, "? %s %s", a.Type, a.Project)
+	}
+
+	for _, change := range a.Changes {
+		fmt.Fprintf(&sb, "\n    %s: %q -> %q", change.Field, change.From, change.To)
+	}
+
+	return sb.String()
+}
+
+// Plan is an ordered list of actions that reconcile the server with a desired state.
+//
+// Projects are created parents first, and deleted children first.
+// Patches are applied after all projects have been created, so that
+// projects can be moved to new parents.
+type Plan struct {
+	Actions []Action
+
+	// projectUUIDs holds the UUIDs of all projects that existed
+	// on the server when the plan was made, for resolving parents.
+	projectUUIDs map[ProjectRef]uuid.UUID
+}
+
+// IsEmpty reports whether the plan contains no actions, i.e. whether the server is in sync.
+func (p Plan) IsEmpty() bool {
+	return len(p.Actions) == 0
+}
+
+// String renders the plan in a human-readable form, suitable for dry-runs.
+func (p Plan) String() string {
+	if p.IsEmpty() {
+		return "no changes"
+	}
+
+	lines := make([]string, 0, len(p.Actions))
+	for _, action := range p.Actions {
+		lines = append(lines, action.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// PlanOptions customizes how plans are made.
+type PlanOptions struct {
+	// Prune causes projects that are not part of the desired state to be deleted,
+	// as well as properties of managed projects that are not part of the desired state.
+	// Use with care: without it, plans never delete anything.
+	Prune bool
+}
+
+// NewPlan diffs the desired state against the projects and project properties on the server,
+// and returns the actions necessary to reconcile them.
+func NewPlan(ctx context.Context, client *dtrack.Client, desired State, opts PlanOptions) (plan Plan, err error) {
+	err = desired.Validate()
+	if err != nil {
+		return
+	}
+
+	current, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Project], error) {
+		return client.Project.GetAll(ctx, po)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to fetch projects: %w", err)
+		return
+	}
+
+	plan.projectUUIDs = make(map[ProjectRef]uuid.UUID, len(current))
+	currentByRef := make(map[ProjectRef]dtrack.Project, len(current))
+	for _, project := range current {
+		ref := ProjectRef{Name: project.Name, Version: project.Version}
+		plan.projectUUIDs[ref] = project.UUID
+		currentByRef[ref] = project
+	}
+
+	desiredByRef := make(map[ProjectRef]Project, len(desired.Projects))
+	for _, project := range desired.Projects {
+		desiredByRef[project.Ref()] = project
+	}
+
+	for _, project := range desired.Projects {
+		if project.Parent == nil {
+			continue
+		}
+		if _, ok := desiredByRef[*project.Parent]; ok {
+			continue
+		}
+		if _, ok := currentByRef[*project.Parent]; !ok {
+			err = fmt.Errorf("%s: parent %s does not exist", project.Ref(), *project.Parent)
+			return
+		}
+		if opts.Prune {
+			err = fmt.Errorf("%s: parent %s is not part of the desired state, and would be pruned", project.Ref(), *project.Parent)
+			return
+		}
+	}
+
+	var creates, patches, propertyActions, deletes []Action
+
+	for _, project := range sortParentsFirst(desired.Projects, desiredByRef) {
+		currentProject, exists := currentByRef[project.Ref()]
+		if !exists {
+			creates = append(creates, Action{
+				Type:    ActionCreateProject,
+				Project: project.Ref(),
+				Desired: project,
+			})
+			propertyActions = append(propertyActions, diffProperties(project, uuid.Nil, nil, false)...)
+			continue
+		}
+
+		if changes := diffProject(project, currentProject, currentByRef); len(changes) > 0 {
+			patches = append(patches, Action{
+				Type:        ActionPatchProject,
+				Project:     project.Ref(),
+				ProjectUUID: currentProject.UUID,
+				Desired:     project,
+				Changes:     changes,
+			})
+		}
+
+		var currentProperties []dtrack.ProjectProperty
+		currentProperties, err = dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.ProjectProperty], error) {
+			return client.ProjectProperty.GetAll(ctx, currentProject.UUID, po)
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to fetch properties of %s: %w", project.Ref(), err)
+			return
+		}
+
+		propertyActions = append(propertyActions, diffProperties(project, currentProject.UUID, currentProperties, opts.Prune)...)
+	}
+
+	if opts.Prune {
+		for _, project := range sortChildrenFirst(current) {
+			ref := ProjectRef{Name: project.Name, Version: project.Version}
+			if _, ok := desiredByRef[ref]; ok {
+				continue
+			}
+
+			// Deleting the project would delete its children as well.
+			for _, child := range current {
+				childRef := ProjectRef{Name: child.Name, Version: child.Version}
+				desiredChild, managed := desiredByRef[childRef]
+				if managed && desiredChild.Parent == nil && child.Parent != nil && child.Parent.UUID == project.UUID {
+					err = fmt.Errorf("%s: parent %s is not part of the desired state, and would be pruned", childRef, ref)
+					return
+				}
+			}
+
+			deletes = append(deletes, Action{
+				Type:        ActionDeleteProject,
+				Project:     ref,
+				ProjectUUID: project.UUID,
+			})
+		}
+	}
+
+	plan.Actions = append(plan.Actions, creates...)
+	plan.Actions = append(plan.Actions, patches...)
+	plan.Actions = append(plan.Actions, propertyActions...)
+	plan.Actions = append(plan.Actions, deletes...)
+
+	return
+}
+
+// diffProject compares the managed fields of a desired project with its current state.
+func diffProject(desired Project, current dtrack.Project, currentByRef map[ProjectRef]dtrack.Project) (changes []Change) {
+	if desired.Classifier != "" && desired.Classifier != current.Classifier {
+		changes = append(changes, Change{Field: "classifier", From: current.Classifier, To: desired.Classifier})
+	}
+	if desired.Description != "" && desired.Description != current.Description {
+		changes = append(changes, Change{Field: "description", From: current.Description, To: desired.Description})
+	}
+	if desired.Active != nil && *desired.Active != current.Active {
+		changes = append(changes, Change{Field: "active", From: fmt.Sprint(current.Active), To: fmt.Sprint(*desired.Active)})
+	}
+
+	if len(desired.Tags) > 0 {
+		currentTags := make([]string, 0, len(current.Tags))
+		for _, tag := range current.Tags {
+			currentTags = append(currentTags, tag.Name)
+		}
+
+		from, to := joinSorted(currentTags), joinSorted(desired.Tags)
+		if from != to {
+			changes = append(changes, Change{Field: "tags", From: from, To: to})
+		}
+	}
+
+	if desired.Parent != nil {
+		var currentParent string
+		if current.Parent != nil {
+			currentParent = current.Parent.UUID.String()
+			// Resolve the parent's identity, as the server may only return its UUID.
+			for ref, project := range currentByRef {
+				if project.UUID == current.Parent.UUID {
+					currentParent = ref.String()
+					break
+				}
+			}
+		}
+
+		if currentParent != desired.Parent.String() {
+			changes = append(changes, Change{Field: "parent", From: currentParent, To: desired.Parent.String()})
+		}
+	}
+
+	return
+}
+
+// diffProperties compares the desired properties of a project with its current ones.
+func diffProperties(desired Project, projectUUID uuid.UUID, current []dtrack.ProjectProperty, prune bool) (actions []Action) {
+	currentByKey := make(map[propertyKey]dtrack.ProjectProperty, len(current))
+	for _, property := range current {
+		currentByKey[propertyKey{group: property.Group, name: property.Name}] = property
+	}
+
+	desiredKeys := make(map[propertyKey]bool, len(desired.Properties))
+	for _, property := range desired.Properties {
+		key := propertyKey{group: property.Group, name: property.Name}
+		desiredKeys[key] = true
+
+		propertyType := property.Type
+		if propertyType == "" {
+			propertyType = defaultPropertyType
+		}
+
+		desiredProperty := dtrack.ProjectProperty{
+			Group:       property.Group,
+			Name:        property.Name,
+			Value:       property.Value,
+			Type:        propertyType,
+			Description: property.Description,
+		}
+
+		currentProperty, exists := currentByKey[key]
+		if !exists {
+			actions = append(actions, Action{
+				Type:        ActionCreateProperty,
+				Project:     desired.Ref(),
+				ProjectUUID: projectUUID,
+				Property:    desiredProperty,
+			})
+			continue
+		}
+
+		var changes []Change
+		if currentProperty.Value != desiredProperty.Value {
+			changes = append(changes, Change{Field: "value", From: currentProperty.Value, To: desiredProperty.Value})
+		}
+		if desiredProperty.Description != "" && currentProperty.Description != desiredProperty.Description {
+			changes = append(changes, Change{Field: "description", From: currentProperty.Description, To: desiredProperty.Description})
+		}
+		if len(changes) > 0 {
+			actions = append(actions, Action{
+				Type:        ActionUpdateProperty,
+				Project:     desired.Ref(),
+				ProjectUUID: projectUUID,
+				Changes:     changes,
+				Property:    desiredProperty,
+			})
+		}
+	}
+
+	if prune {
+		for _, property := range current {
+			if desiredKeys[propertyKey{group: property.Group, name: property.Name}] {
+				continue
+			}
+
+			actions = append(actions, Action{
+				Type:        ActionDeleteProperty,
+				Project:     desired.Ref(),
+				ProjectUUID: projectUUID,
+				Property:    property,
+			})
+		}
+	}
+
+	return
+}
+
+// sortParentsFirst orders projects so that parents defined in the desired state precede their children.
+// The relative order of projects is retained otherwise. Projects must not form cycles.
+func sortParentsFirst(projects []Project, projectsByRef map[ProjectRef]Project) []Project {
+	sorted := make([]Project, 0, len(projects))
+	added := make(map[ProjectRef]bool, len(projects))
+
+	var add func(project Project)
+	add = func(project Project) {
+		if added[project.Ref()] {
+			return
+		}
+		if project.Parent != nil {
+			if parent, ok := projectsByRef[*project.Parent]; ok {
+				add(parent)
+			}
+		}
+
+		added[project.Ref()] = true
+		sorted = append(sorted, project)
+	}
+
+	for _, project := range projects {
+		add(project)
+	}
+
+	return sorted
+}
+
+// sortChildrenFirst orders projects by their depth in the project hierarchy, deepest first.
+// The server deletes children along with their parent, so they must be deleted first.
+func sortChildrenFirst(projects []dtrack.Project) []dtrack.Project {
+	parents := make(map[uuid.UUID]uuid.UUID, len(projects))
+	for _, project := range projects {
+		if project.Parent != nil {
+			parents[project.UUID] = project.Parent.UUID
+		}
+	}
+
+	depth := func(projectUUID uuid.UUID) (d int) {
+		for parentUUID, ok := parents[projectUUID]; ok && d < len(projects); parentUUID, ok = parents[parentUUID] {
+			d++
+		}
+		return
+	}
+
+	sorted := make([]dtrack.Project, len(projects))
+	copy(sorted, projects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return depth(sorted[i].UUID) > depth(sorted[j].UUID)
+	})
+
+	return sorted
+}
+
+func joinSorted(values []string) string {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
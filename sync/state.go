@@ -0,0 +1,145 @@
+package sync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is the desired state of projects.
+type State struct {
+	Projects []Project `yaml:"projects"`
+}
+
+// Project is the desired state of a single project.
+//
+// Fields that are not set are not managed, and thus left untouched on the server.
+// Tags are managed when at least one tag is given, and replace all tags of the project.
+type Project struct {
+	Name        string      `yaml:"name"`
+	Version     string      `yaml:"version,omitempty"`
+	Classifier  string      `yaml:"classifier,omitempty"`
+	Description string      `yaml:"description,omitempty"`
+	Active      *bool       `yaml:"active,omitempty"`
+	Tags        []string    `yaml:"tags,omitempty"`
+	Parent      *ProjectRef `yaml:"parent,omitempty"`
+	Properties  []Property  `yaml:"properties,omitempty"`
+}
+
+// Ref returns a reference to p.
+func (p Project) Ref() ProjectRef {
+	return ProjectRef{Name: p.Name, Version: p.Version}
+}
+
+// ProjectRef identifies a project by its name and version.
+type ProjectRef struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version,omitempty"`
+}
+
+func (r ProjectRef) String() string {
+	if r.Version == "" {
+		return r.Name
+	}
+
+	return r.Name + "@" + r.Version
+}
+
+// Property is the desired state of a project property.
+type Property struct {
+	Group       string `yaml:"group"`
+	Name        string `yaml:"name"`
+	Value       string `yaml:"value"`
+	Type        string `yaml:"type,omitempty"` // Defaults to STRING
+	Description string `yaml:"description,omitempty"`
+}
+
+// defaultPropertyType is the type of properties that don't specify a type.
+const defaultPropertyType = "STRING"
+
+// Decode decodes and validates a desired state from YAML.
+// Unknown fields are rejected, so that typos don't go unnoticed.
+func Decode(r io.Reader) (state State, err error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	err = dec.Decode(&state)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = fmt.Errorf("state is empty")
+		} else {
+			err = fmt.Errorf("failed to decode state: %w", err)
+		}
+		return
+	}
+
+	err = state.Validate()
+	return
+}
+
+// DecodeBytes is like Decode, but reads the state from data.
+func DecodeBytes(data []byte) (State, error) {
+	return Decode(bytes.NewReader(data))
+}
+
+// Validate checks that all projects are named and unique, that properties are named and unique
+// per project, and that parents within the state don't form cycles.
+func (s State) Validate() error {
+	projects := make(map[ProjectRef]Project, len(s.Projects))
+	for i, project := range s.Projects {
+		if project.Name == "" {
+			return fmt.Errorf("projects[%d]: name is required", i)
+		}
+		if _, ok := projects[project.Ref()]; ok {
+			return fmt.Errorf("projects[%d]: %s is defined more than once", i, project.Ref())
+		}
+		projects[project.Ref()] = project
+
+		if project.Parent != nil && project.Parent.Name == "" {
+			return fmt.Errorf("projects[%d]: parent name is required", i)
+		}
+
+		properties := make(map[propertyKey]bool, len(project.Properties))
+		for j, property := range project.Properties {
+			if property.Group == "" || property.Name == "" {
+				return fmt.Errorf("projects[%d].properties[%d]: group and name are required", i, j)
+			}
+
+			key := propertyKey{group: property.Group, name: property.Name}
+			if properties[key] {
+				return fmt.Errorf("projects[%d].properties[%d]: %s is defined more than once", i, j, key)
+			}
+			properties[key] = true
+		}
+	}
+
+	for _, project := range s.Projects {
+		seen := map[ProjectRef]bool{project.Ref(): true}
+		for parent := project.Parent; parent != nil; {
+			if seen[*parent] {
+				return fmt.Errorf("%s: parents form a cycle", project.Ref())
+			}
+			seen[*parent] = true
+
+			parentProject, ok := projects[*parent]
+			if !ok {
+				break
+			}
+			parent = parentProject.Parent
+		}
+	}
+
+	return nil
+}
+
+type propertyKey struct {
+	group string
+	name  string
+}
+
+func (k propertyKey) String() string {
+	return k.group + "/" + k.name
+}
@@ -0,0 +1,164 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/nscuro/dtrack-client"
+)
+
+const (
+	acmeUUID    = "c6fd6ae4-c2d7-4c38-9ab1-6a6d1ae8c8c0"
+	acmeAppUUID = "2d16089e-6d3a-437e-b334-f27eb2cbd7f4"
+	legacyUUID  = "0e4c0bc4-9d5a-4a4a-a5b0-c2ba29a6a8c9"
+	acmeNewUUID = "7a5c3f83-3e9e-4f0b-8a4b-b35a4e1b4c7e"
+)
+
+func TestDecode(t *testing.T) {
+	state, err := Decode(strings.NewReader(`
+projects:
+  - name: acme
+    active: true
+    tags: [product-line]
+  - name: acme-app
+    version: 1.0.0
+    parent:
+      name: acme
+    properties:
+      - group: ownership
+        name: team
+        value: team-a
+`))
+	require.NoError(t, err)
+	require.Len(t, state.Projects, 2)
+	require.NotNil(t, state.Projects[0].Active)
+	require.True(t, *state.Projects[0].Active)
+	require.Equal(t, []string{"product-line"}, state.Projects[0].Tags)
+	require.Equal(t, &ProjectRef{Name: "acme"}, state.Projects[1].Parent)
+	require.Equal(t, []Property{{Group: "ownership", Name: "team", Value: "team-a"}}, state.Projects[1].Properties)
+
+	_, err = Decode(strings.NewReader("projects:\n  - name: acme\n    clasifier: APPLICATION\n"))
+	require.ErrorContains(t, err, "clasifier")
+
+	_, err = Decode(strings.NewReader("projects:\n  - name: acme\n  - name: acme\n"))
+	require.ErrorContains(t, err, "acme is defined more than once")
+
+	_, err = Decode(strings.NewReader("projects:\n  - name: a\n    parent: {name: b}\n  - name: b\n    parent: {name: a}\n"))
+	require.ErrorContains(t, err, "parents form a cycle")
+}
+
+func TestReconcile(t *testing.T) {
+	httpClient := &http.Client{}
+	httpmock.ActivateNonDefault(httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	client, err := dtrack.NewClient("http://localhost", dtrack.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project",
+		func(req *http.Request) (*http.Response, error) {
+			res := httpmock.NewStringResponse(http.StatusOK, `[
+	{"uuid":"`+acmeUUID+`","name":"acme","classifier":"APPLICATION","active":true},
+	{"uuid":"`+acmeAppUUID+`","name":"acme-app","version":"1.0.0","classifier":"LIBRARY","active":true,"tags":[{"name":"java"}]},
+	{"uuid":"`+legacyUUID+`","name":"legacy","active":false}
+]`)
+			res.Header.Set("X-Total-Count", "3")
+			return res, nil
+		})
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+acmeUUID+"/property",
+		httpmock.NewStringResponder(http.StatusOK, `[]`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+acmeAppUUID+"/property",
+		func(req *http.Request) (*http.Response, error) {
+			res := httpmock.NewStringResponse(http.StatusOK, `[
+	{"groupName":"ownership","propertyName":"team","propertyValue":"team-b","propertyType":"STRING"},
+	{"groupName":"misc","propertyName":"old","propertyValue":"1","propertyType":"STRING"}
+]`)
+			res.Header.Set("X-Total-Count", "2")
+			return res, nil
+		})
+
+	state, err := DecodeBytes([]byte(`
+projects:
+  - name: acme-app
+    version: 1.0.0
+    classifier: APPLICATION
+    parent:
+      name: acme-new
+    properties:
+      - group: ownership
+        name: team
+        value: team-a
+      - group: ownership
+        name: email
+        value: team-a@example.com
+  - name: acme-new
+  - name: acme
+    classifier: APPLICATION
+`))
+	require.NoError(t, err)
+
+	var output strings.Builder
+	plan, err := Reconcile(context.TODO(), client, state, Options{
+		PlanOptions: PlanOptions{Prune: true},
+		DryRun:      true,
+		Output:      &output,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, `+ project acme-new
+~ project acme-app@1.0.0
+    classifier: "LIBRARY" -> "APPLICATION"
+    parent: "" -> "acme-new"
+~ property acme-app@1.0.0 ownership/team
+    value: "team-b" -> "team-a"
++ property acme-app@1.0.0 ownership/email = "team-a@example.com"
+- property acme-app@1.0.0 misc/old
+- project legacy
+`, output.String())
+
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project",
+		func(req *http.Request) (*http.Response, error) {
+			var project dtrack.Project
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&project))
+			require.Equal(t, "acme-new", project.Name)
+			require.True(t, project.Active)
+			return httpmock.NewStringResponse(http.StatusCreated, `{"uuid":"`+acmeNewUUID+`","name":"acme-new"}`), nil
+		})
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+acmeAppUUID,
+		httpmock.NewStringResponder(http.StatusOK, `{"uuid":"`+acmeAppUUID+`","name":"acme-app","version":"1.0.0","classifier":"LIBRARY","active":true,"tags":[{"name":"java"}]}`))
+	httpmock.RegisterResponder(http.MethodPatch, "http://localhost/api/v1/project/"+acmeAppUUID,
+		func(req *http.Request) (*http.Response, error) {
+			var project dtrack.Project
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&project))
+			require.Equal(t, "APPLICATION", project.Classifier)
+			require.True(t, project.Active)
+			require.Equal(t, []dtrack.Tag{{Name: "java"}}, project.Tags)
+			require.NotNil(t, project.Parent)
+			require.Equal(t, acmeNewUUID, project.Parent.UUID.String())
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		})
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/project/"+acmeAppUUID+"/property",
+		httpmock.NewStringResponder(http.StatusOK, `{}`))
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project/"+acmeAppUUID+"/property",
+		httpmock.NewStringResponder(http.StatusCreated, `{}`))
+	httpmock.RegisterResponder(http.MethodDelete, "http://localhost/api/v1/project/"+acmeAppUUID+"/property",
+		httpmock.NewStringResponder(http.StatusNoContent, ``))
+	httpmock.RegisterResponder(http.MethodDelete, "http://localhost/api/v1/project/"+legacyUUID,
+		httpmock.NewStringResponder(http.StatusNoContent, ``))
+
+	require.NoError(t, Apply(context.TODO(), client, plan))
+
+	callCount := httpmock.GetCallCountInfo()
+	require.Equal(t, 1, callCount["PUT http://localhost/api/v1/project"])
+	require.Equal(t, 1, callCount["PATCH http://localhost/api/v1/project/"+acmeAppUUID])
+	require.Equal(t, 1, callCount["POST http://localhost/api/v1/project/"+acmeAppUUID+"/property"])
+	require.Equal(t, 1, callCount["PUT http://localhost/api/v1/project/"+acmeAppUUID+"/property"])
+	require.Equal(t, 1, callCount["DELETE http://localhost/api/v1/project/"+acmeAppUUID+"/property"])
+	require.Equal(t, 1, callCount["DELETE http://localhost/api/v1/project/"+legacyUUID])
+}
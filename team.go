@@ -23,7 +23,7 @@ type TeamService struct {
 }
 
 func (ts TeamService) Get(ctx context.Context, teamUUID uuid.UUID) (p Project, err error) {
-	req, err := ts.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/team/%s", teamUUID))
+	req, err := ts.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/team/%s", teamUUID), withRedactedBodies())
 	if err != nil {
 		return
 	}
@@ -33,7 +33,7 @@ func (ts TeamService) Get(ctx context.Context, teamUUID uuid.UUID) (p Project, e
 }
 
 func (ts TeamService) GetAll(ctx context.Context, po PageOptions) (p Page[Team], err error) {
-	req, err := ts.client.newRequest(ctx, http.MethodGet, "/api/v1/team", withPageOptions(po))
+	req, err := ts.client.newRequest(ctx, http.MethodGet, "/api/v1/team", withPageOptions(po), withRedactedBodies())
 	if err != nil {
 		return
 	}
@@ -48,7 +48,7 @@ func (ts TeamService) GetAll(ctx context.Context, po PageOptions) (p Page[Team],
 }
 
 func (ts TeamService) GenerateAPIKey(ctx context.Context, teamUUID uuid.UUID) (key string, err error) {
-	req, err := ts.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/team/%s/key", teamUUID))
+	req, err := ts.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/team/%s/key", teamUUID), withRedactedBodies())
 	if err != nil {
 		return
 	}
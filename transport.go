@@ -0,0 +1,180 @@
+package dtrack
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// httpConfig collects the HTTP related options of a client.
+// The options are only applied once all of them are known,
+// so that the order in which they're provided doesn't matter.
+type httpConfig struct {
+	client             *http.Client
+	timeout            *time.Duration
+	tlsConfig          *tls.Config
+	clientCertificates []tls.Certificate
+	rootCAs            *x509.CertPool
+	proxy              func(*http.Request) (*url.URL, error)
+}
+
+func (hc httpConfig) customizesTransport() bool {
+	return hc.tlsConfig != nil ||
+		len(hc.clientCertificates) > 0 ||
+		hc.rootCAs != nil ||
+		hc.proxy != nil
+}
+
+// WithHTTPClient overrides the HTTP client used to send requests.
+//
+// The given client is not modified. Options affecting the transport, like WithAPIKey
+// or WithTLSConfig, are applied to a copy of it. Its timeout is retained, unless
+// WithTimeout is provided as well.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) error {
+		if client == nil {
+			return fmt.Errorf("no http client provided")
+		}
+
+		c.httpConfig.client = client
+		return nil
+	}
+}
+
+// WithTLSConfig overrides the TLS configuration used to connect to Dependency-Track.
+//
+// Certificates and root CAs provided via WithClientCertificate and WithRootCAs
+// take precedence over their counterparts in the given configuration.
+// Requires the transport of the HTTP client to be an *http.Transport.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) error {
+		if config == nil {
+			return fmt.Errorf("no tls config provided")
+		}
+
+		c.httpConfig.tlsConfig = config.Clone()
+		return nil
+	}
+}
+
+// WithClientCertificate enables mutual TLS authentication using the certificate
+// and private key in the given PEM encoded files.
+// Requires the transport of the HTTP client to be an *http.Transport.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(c *Client) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		c.httpConfig.clientCertificates = append(c.httpConfig.clientCertificates, cert)
+		return nil
+	}
+}
+
+// WithRootCAs overrides the certificate authorities used to verify the certificate
+// presented by Dependency-Track, e.g. to trust a corporate CA.
+// Requires the transport of the HTTP client to be an *http.Transport.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) error {
+		if pool == nil {
+			return fmt.Errorf("no cert pool provided")
+		}
+
+		c.httpConfig.rootCAs = pool
+		return nil
+	}
+}
+
+// WithProxy sends all requests through the proxy at the given URL,
+// instead of the proxy determined by the environment.
+// Requires the transport of the HTTP client to be an *http.Transport.
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) error {
+		if proxyURL == "" {
+			return fmt.Errorf("no proxy url provided")
+		}
+
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		c.httpConfig.proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// buildHTTPClient assembles the HTTP client according to the client's options.
+func (c Client) buildHTTPClient() (*http.Client, error) {
+	httpClient := &http.Client{
+		Timeout: DefaultTimeout,
+	}
+	if c.httpConfig.client != nil {
+		clientCopy := *c.httpConfig.client
+		httpClient = &clientCopy
+	}
+
+	if c.httpConfig.timeout != nil {
+		httpClient.Timeout = *c.httpConfig.timeout
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if c.httpConfig.customizesTransport() {
+		baseTransport, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("tls and proxy options require the transport to be *http.Transport, but got %T", transport)
+		}
+
+		customTransport := baseTransport.Clone()
+		customTransport.TLSClientConfig = c.httpConfig.buildTLSConfig(customTransport.TLSClientConfig)
+		if c.httpConfig.proxy != nil {
+			customTransport.Proxy = c.httpConfig.proxy
+		}
+
+		transport = customTransport
+	}
+
+	for _, authHeader := range c.authHeaders {
+		transport = &authHeaderTransport{
+			name:      authHeader.name,
+			value:     authHeader.value,
+			transport: transport,
+		}
+	}
+
+	if transport != http.DefaultTransport {
+		httpClient.Transport = transport
+	}
+
+	return httpClient, nil
+}
+
+// buildTLSConfig merges the TLS related options into the given base configuration.
+func (hc httpConfig) buildTLSConfig(base *tls.Config) *tls.Config {
+	var config *tls.Config
+	switch {
+	case hc.tlsConfig != nil:
+		config = hc.tlsConfig.Clone()
+	case base != nil:
+		config = base.Clone()
+	default:
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if len(hc.clientCertificates) > 0 {
+		config.Certificates = append(config.Certificates, hc.clientCertificates...)
+	}
+	if hc.rootCAs != nil {
+		config.RootCAs = hc.rootCAs
+	}
+
+	return config
+}
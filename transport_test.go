@@ -0,0 +1,143 @@
+package dtrack
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		_, _ = w.Write([]byte(`[{"name":"acme-app"}]`))
+	}))
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	// Auth must be retained regardless of option order.
+	for _, options := range [][]ClientOption{
+		{WithAPIKey("secret"), WithRootCAs(rootCAs)},
+		{WithRootCAs(rootCAs), WithAPIKey("secret")},
+		{WithAPIKey("secret"), WithHTTPClient(&http.Client{}), WithRootCAs(rootCAs)},
+	} {
+		client, err := NewClient(server.URL, options...)
+		require.NoError(t, err)
+
+		projects, err := client.Project.GetAll(context.TODO(), PageOptions{})
+		require.NoError(t, err)
+		require.Len(t, projects.Items, 1)
+	}
+
+	client, err := NewClient(server.URL, WithAPIKey("secret"))
+	require.NoError(t, err)
+
+	_, err = client.Project.GetAll(context.TODO(), PageOptions{})
+	require.Error(t, err) // Certificate is not trusted
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	certFile, keyFile := generateClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Len(t, r.TLS.PeerCertificates, 1)
+		require.Equal(t, "dtrack-client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		_, _ = w.Write([]byte(`{"version":"4.7.0"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewClient(server.URL,
+		WithClientCertificate(certFile, keyFile),
+		WithTLSConfig(server.Client().Transport.(*http.Transport).TLSClientConfig))
+	require.NoError(t, err)
+
+	about, err := client.About.Get(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, "4.7.0", about.Version)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	client, err := NewClient("http://localhost", WithHTTPClient(httpClient), WithAPIKey("secret"), WithTimeout(time.Second))
+	require.NoError(t, err)
+
+	require.Equal(t, time.Second, client.httpClient.Timeout)
+	require.IsType(t, &authHeaderTransport{}, client.httpClient.Transport)
+
+	// The provided client must not be modified.
+	require.Equal(t, time.Minute, httpClient.Timeout)
+	require.Nil(t, httpClient.Transport)
+}
+
+func TestWithProxy(t *testing.T) {
+	_, err := NewClient("http://localhost", WithProxy("http://proxy.example.com:3128"), WithHTTPClient(&http.Client{
+		Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil }),
+	}))
+	require.Error(t, err) // Proxy can't be applied to custom transports
+
+	client, err := NewClient("http://localhost", WithProxy("http://proxy.example.com:3128"))
+	require.NoError(t, err)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api/version", nil)
+	require.NoError(t, err)
+
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:3128", proxyURL.String())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func generateClientCertificate(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dtrack-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return
+}
@@ -1,6 +1,15 @@
 package dtrack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultPageSize        = 50
+	defaultPageConcurrency = 4
+)
 
 // FetchAll is a convenience function to retrieve all items of a paginated API resource.
 func FetchAll[T any](pageFetchFunc func(po PageOptions) (Page[T], error)) (items []T, err error) {
@@ -14,7 +23,7 @@ func FetchAll[T any](pageFetchFunc func(po PageOptions) (Page[T], error)) (items
 
 // ForEach is a convenience function to perform an action on every item of a paginated API resource.
 func ForEach[T any](pageFetchFunc func(po PageOptions) (Page[T], error), handlerFunc func(item T) error) (err error) {
-	const pageSize = 50
+	const pageSize = defaultPageSize
 
 	var (
 		page       Page[T]
@@ -38,7 +47,7 @@ func ForEach[T any](pageFetchFunc func(po PageOptions) (Page[T], error), handler
 			}
 		}
 
-		itemsSeen += page.TotalCount
+		itemsSeen += len(page.Items)
 		if len(page.Items) == 0 || itemsSeen >= page.TotalCount {
 			break
 		}
@@ -48,3 +57,156 @@ func ForEach[T any](pageFetchFunc func(po PageOptions) (Page[T], error), handler
 
 	return
 }
+
+// ConcurrencyOptions controls how paginated API resources are fetched concurrently.
+type ConcurrencyOptions struct {
+	PageSize    int // Amount of elements to fetch per page; defaults to 50
+	Concurrency int // Maximum number of pages to fetch at the same time; defaults to 4
+}
+
+// FetchAllConcurrent is like FetchAll, but fetches pages concurrently.
+// Items are returned in the same order as they would be by FetchAll.
+func FetchAllConcurrent[T any](ctx context.Context, pageFetchFunc func(ctx context.Context, po PageOptions) (Page[T], error), opts ConcurrencyOptions) (items []T, err error) {
+	var (
+		pages     = make(map[int][]T)
+		pageCount = 0
+	)
+
+	err = forEachPageConcurrent(ctx, pageFetchFunc, opts, func(pageNumber int, page Page[T]) error {
+		pages[pageNumber] = page.Items
+		if pageNumber > pageCount {
+			pageCount = pageNumber
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for pageNumber := 1; pageNumber <= pageCount; pageNumber++ {
+		items = append(items, pages[pageNumber]...)
+	}
+
+	return
+}
+
+// ForEachConcurrent is like ForEach, but fetches pages concurrently.
+//
+// The first page is fetched on its own to determine the total number of pages.
+// The remaining pages are then fetched by a bounded pool of workers.
+// handlerFunc is never invoked concurrently, but pages are handled in the order
+// they arrive in, which is not necessarily the order of their page numbers.
+//
+// Fetching stops as soon as ctx is canceled, or an error occurs while fetching or handling.
+func ForEachConcurrent[T any](ctx context.Context, pageFetchFunc func(ctx context.Context, po PageOptions) (Page[T], error), handlerFunc func(item T) error, opts ConcurrencyOptions) error {
+	return forEachPageConcurrent(ctx, pageFetchFunc, opts, func(pageNumber int, page Page[T]) error {
+		for i := range page.Items {
+			if err := handlerFunc(page.Items[i]); err != nil {
+				return fmt.Errorf("failed to handle item %d on page %d: %w", i+1, pageNumber, err)
+			}
+		}
+		return nil
+	})
+}
+
+type pageResult[T any] struct {
+	pageNumber int
+	page       Page[T]
+	err        error
+}
+
+func forEachPageConcurrent[T any](ctx context.Context, pageFetchFunc func(ctx context.Context, po PageOptions) (Page[T], error), opts ConcurrencyOptions, pageHandlerFunc func(pageNumber int, page Page[T]) error) error {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPageConcurrency
+	}
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	firstPage, err := pageFetchFunc(fetchCtx, PageOptions{PageNumber: 1, PageSize: pageSize})
+	if err != nil {
+		return err
+	}
+	if err = pageHandlerFunc(1, firstPage); err != nil {
+		return err
+	}
+
+	if len(firstPage.Items) == 0 || len(firstPage.Items) >= firstPage.TotalCount {
+		return nil
+	}
+
+	// The server may cap the page size below the requested one.
+	// Continue with the effective page size, so no items are skipped.
+	if len(firstPage.Items) < pageSize {
+		pageSize = len(firstPage.Items)
+	}
+
+	pageCount := (firstPage.TotalCount + pageSize - 1) / pageSize
+	if concurrency > pageCount-1 {
+		concurrency = pageCount - 1
+	}
+
+	var (
+		pageNumbers = make(chan int)
+		results     = make(chan pageResult[T])
+		wg          sync.WaitGroup
+	)
+
+	go func() {
+		defer close(pageNumbers)
+		for pageNumber := 2; pageNumber <= pageCount; pageNumber++ {
+			select {
+			case pageNumbers <- pageNumber:
+			case <-fetchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pageNumber := range pageNumbers {
+				page, fetchErr := pageFetchFunc(fetchCtx, PageOptions{PageNumber: pageNumber, PageSize: pageSize})
+				select {
+				case results <- pageResult[T]{pageNumber: pageNumber, page: page, err: fetchErr}:
+				case <-fetchCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if err != nil {
+			continue // Drain remaining results
+		}
+
+		if result.err != nil {
+			err = fmt.Errorf("failed to fetch page %d: %w", result.pageNumber, result.err)
+		} else {
+			err = pageHandlerFunc(result.pageNumber, result.page)
+		}
+
+		if err != nil {
+			cancel()
+		}
+	}
+
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	return err
+}
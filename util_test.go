@@ -0,0 +1,137 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newPageFetchFunc(totalCount int) func(ctx context.Context, po PageOptions) (Page[int], error) {
+	return func(ctx context.Context, po PageOptions) (Page[int], error) {
+		page := Page[int]{TotalCount: totalCount}
+		for i := (po.PageNumber - 1) * po.PageSize; i < po.PageNumber*po.PageSize && i < totalCount; i++ {
+			page.Items = append(page.Items, i)
+		}
+		return page, ctx.Err()
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	fetch := newPageFetchFunc(237)
+
+	items, err := FetchAll(func(po PageOptions) (Page[int], error) {
+		return fetch(context.TODO(), po)
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 237)
+	for i := range items {
+		require.Equal(t, i, items[i])
+	}
+}
+
+func TestFetchAllConcurrent(t *testing.T) {
+	var inFlight, maxInFlight int32
+	fetch := newPageFetchFunc(1234)
+
+	items, err := FetchAllConcurrent(context.TODO(), func(ctx context.Context, po PageOptions) (Page[int], error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+
+		// Let later pages complete first, to ensure ordering is restored.
+		time.Sleep(time.Duration(20-po.PageNumber) * time.Millisecond)
+		return fetch(ctx, po)
+	}, ConcurrencyOptions{PageSize: 100, Concurrency: 3})
+	require.NoError(t, err)
+	require.Len(t, items, 1234)
+	for i := range items {
+		require.Equal(t, i, items[i])
+	}
+	require.LessOrEqual(t, maxInFlight, int32(3))
+}
+
+func TestFetchAllConcurrent_SinglePage(t *testing.T) {
+	var calls int32
+	fetch := newPageFetchFunc(10)
+
+	items, err := FetchAllConcurrent(context.TODO(), func(ctx context.Context, po PageOptions) (Page[int], error) {
+		atomic.AddInt32(&calls, 1)
+		return fetch(ctx, po)
+	}, ConcurrencyOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 10)
+	require.Equal(t, int32(1), calls)
+}
+
+func TestFetchAllConcurrent_CappedPageSize(t *testing.T) {
+	const maxPageSize = 30
+	fetch := newPageFetchFunc(250)
+
+	items, err := FetchAllConcurrent(context.TODO(), func(ctx context.Context, po PageOptions) (Page[int], error) {
+		if po.PageSize > maxPageSize {
+			po.PageSize = maxPageSize
+		}
+		return fetch(ctx, po)
+	}, ConcurrencyOptions{PageSize: 100, Concurrency: 3})
+	require.NoError(t, err)
+	require.Len(t, items, 250)
+	for i := range items {
+		require.Equal(t, i, items[i])
+	}
+}
+
+func TestForEachConcurrent(t *testing.T) {
+	t.Run("FetchError", func(t *testing.T) {
+		errFetch := errors.New("fetch failed")
+		fetch := newPageFetchFunc(1000)
+
+		err := ForEachConcurrent(context.TODO(), func(ctx context.Context, po PageOptions) (Page[int], error) {
+			if po.PageNumber == 5 {
+				return Page[int]{}, errFetch
+			}
+			return fetch(ctx, po)
+		}, func(item int) error {
+			return nil
+		}, ConcurrencyOptions{PageSize: 10})
+		require.ErrorIs(t, err, errFetch)
+		require.Contains(t, err.Error(), "page 5")
+	})
+
+	t.Run("HandlerError", func(t *testing.T) {
+		errHandler := errors.New("handler failed")
+
+		var handled int32
+		err := ForEachConcurrent(context.TODO(), newPageFetchFunc(1000), func(item int) error {
+			if atomic.AddInt32(&handled, 1) == 15 {
+				return errHandler
+			}
+			return nil
+		}, ConcurrencyOptions{PageSize: 10})
+		require.ErrorIs(t, err, errHandler)
+		require.Equal(t, int32(15), handled)
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var handled int32
+		err := ForEachConcurrent(ctx, newPageFetchFunc(1000), func(item int) error {
+			if atomic.AddInt32(&handled, 1) == 25 {
+				cancel()
+			}
+			return nil
+		}, ConcurrencyOptions{PageSize: 10})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Less(t, handled, int32(1000))
+	})
+}
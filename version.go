@@ -0,0 +1,197 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Version is a semantic version, as reported by Dependency-Track.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string // Pre-release identifier, e.g. "SNAPSHOT" for 4.0.0-SNAPSHOT
+}
+
+// ParseVersion parses a version string of the form MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD].
+// Minor and patch components may be omitted, in which case they default to zero.
+func ParseVersion(s string) (v Version, err error) {
+	str := strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	if i := strings.Index(str, "+"); i >= 0 {
+		str = str[:i] // Build metadata is not relevant for comparison
+	}
+	if i := strings.Index(str, "-"); i >= 0 {
+		v.PreRelease = str[i+1:]
+		str = str[:i]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 {
+		err = fmt.Errorf("invalid version %q: too many components", s)
+		return
+	}
+
+	components := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		component, convErr := strconv.Atoi(part)
+		if convErr != nil || component < 0 {
+			err = fmt.Errorf("invalid version %q: invalid component %q", s, part)
+			return
+		}
+		*components[i] = component
+	}
+
+	return
+}
+
+// MustParseVersion is like ParseVersion, but panics when s is not a valid version.
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v Version) String() string {
+	if v.PreRelease != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.PreRelease)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare compares v to other. It returns -1 if v is lower than other,
+// 0 if both are equal, and +1 if v is greater than other.
+// Pre-releases are lower than their respective release.
+func (v Version) Compare(other Version) int {
+	if c := v.compareCore(other); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	case v.PreRelease < other.PreRelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// AtLeast determines whether v is greater than or equal to other,
+// ignoring pre-release identifiers. A snapshot of a version is thus
+// considered to provide the same features as its release.
+func (v Version) AtLeast(other Version) bool {
+	return v.compareCore(other) >= 0
+}
+
+func (v Version) compareCore(other Version) int {
+	for _, pair := range [][2]int{
+		{v.Major, other.Major},
+		{v.Minor, other.Minor},
+		{v.Patch, other.Patch},
+	} {
+		if pair[0] < pair[1] {
+			return -1
+		} else if pair[0] > pair[1] {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// ErrUnsupportedServerVersion indicates that an operation is not supported
+// by the version of the Dependency-Track server.
+var ErrUnsupportedServerVersion = errors.New("unsupported server version")
+
+// UnsupportedServerVersionError is returned by services when they're asked to perform
+// an operation that is not available in the detected version of the Dependency-Track server.
+// It matches ErrUnsupportedServerVersion when used with errors.Is.
+type UnsupportedServerVersionError struct {
+	Operation       string  // Name of the operation, e.g. "VEX.Upload"
+	RequiredVersion Version // Minimum server version required by the operation
+	ServerVersion   Version // Detected server version
+}
+
+func (e UnsupportedServerVersionError) Error() string {
+	return fmt.Sprintf("%s requires Dependency-Track %s or newer, but server version is %s",
+		e.Operation, e.RequiredVersion, e.ServerVersion)
+}
+
+func (e UnsupportedServerVersionError) Is(target error) bool {
+	return target == ErrUnsupportedServerVersion
+}
+
+// serverVersionCache holds the lazily detected server version.
+// It is shared by all copies of a Client.
+type serverVersionCache struct {
+	mutex   sync.Mutex
+	version *Version
+}
+
+// WithServerVersion sets the version of the Dependency-Track server,
+// instead of detecting it using AboutService.Get when first needed.
+func WithServerVersion(version string) ClientOption {
+	return func(c *Client) error {
+		v, err := ParseVersion(version)
+		if err != nil {
+			return err
+		}
+
+		c.serverVersion.version = &v
+		return nil
+	}
+}
+
+// ServerVersion provides the version of the Dependency-Track server.
+// The version is retrieved using AboutService.Get on the first invocation, and cached afterwards.
+func (c Client) ServerVersion(ctx context.Context) (Version, error) {
+	c.serverVersion.mutex.Lock()
+	defer c.serverVersion.mutex.Unlock()
+
+	if c.serverVersion.version != nil {
+		return *c.serverVersion.version, nil
+	}
+
+	about, err := c.About.Get(ctx)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to detect server version: %w", err)
+	}
+
+	v, err := ParseVersion(about.Version)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to detect server version: %w", err)
+	}
+
+	c.serverVersion.version = &v
+	return v, nil
+}
+
+// requireServerVersion ensures that the server is at least of the given version,
+// before the calling service method issues any request.
+func (c Client) requireServerVersion(ctx context.Context, required Version) error {
+	serverVersion, err := c.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !serverVersion.AtLeast(required) {
+		return &UnsupportedServerVersionError{
+			Operation:       callerOperation(1),
+			RequiredVersion: required,
+			ServerVersion:   serverVersion,
+		}
+	}
+
+	return nil
+}
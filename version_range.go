@@ -0,0 +1,203 @@
+package dtrack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// VersionRange is a set of version constraints, such as ">=2.0.0, <2.15.0 || >=2.16.0, <2.17.1".
+//
+// Constraints separated by a comma must all be satisfied, alternatives are separated by "||".
+// Supported operators are =, !=, <, <=, > and >=. A constraint without an operator is an exact match.
+//
+// Versions are compared in a best-effort, ecosystem-agnostic manner: numeric segments are compared
+// numerically, and qualifiers such as "-beta1" sort before the version they qualify.
+type VersionRange struct {
+	alternatives [][]versionConstraint
+}
+
+type versionConstraint struct {
+	operator string
+	version  string
+}
+
+// ParseVersionRange parses a version range. See VersionRange for the syntax.
+func ParseVersionRange(s string) (vr VersionRange, err error) {
+	for _, alternative := range strings.Split(s, "||") {
+		var constraints []versionConstraint
+		for _, constraint := range strings.Split(alternative, ",") {
+			constraint = strings.TrimSpace(constraint)
+			if constraint == "" {
+				continue
+			}
+
+			operator := "="
+			for _, op := range []string{">=", "<=", "!=", ">", "<", "="} {
+				if strings.HasPrefix(constraint, op) {
+					operator = op
+					constraint = strings.TrimSpace(constraint[len(op):])
+					break
+				}
+			}
+			if constraint == "" {
+				return VersionRange{}, fmt.Errorf("invalid version range %q: constraint without version", s)
+			}
+
+			constraints = append(constraints, versionConstraint{operator: operator, version: constraint})
+		}
+
+		if len(constraints) == 0 {
+			return VersionRange{}, fmt.Errorf("invalid version range %q: empty alternative", s)
+		}
+		vr.alternatives = append(vr.alternatives, constraints)
+	}
+
+	return
+}
+
+// Contains reports whether version satisfies the range.
+func (vr VersionRange) Contains(version string) bool {
+	for _, constraints := range vr.alternatives {
+		satisfied := true
+		for _, constraint := range constraints {
+			if !constraint.satisfiedBy(version) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (vc versionConstraint) satisfiedBy(version string) bool {
+	cmp := compareVersionStrings(version, vc.version)
+
+	switch vc.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+
+	return false
+}
+
+// compareVersionStrings compares two arbitrary version strings.
+// It returns -1 if a < b, 0 if a == b, and +1 if a > b.
+func compareVersionStrings(a, b string) int {
+	segmentsA, segmentsB := splitVersion(a), splitVersion(b)
+
+	for i := 0; i < len(segmentsA) || i < len(segmentsB); i++ {
+		if i >= len(segmentsA) {
+			if cmp := compareTrailingSegment(segmentsB[i]); cmp != 0 {
+				return -cmp
+			}
+			continue
+		}
+		if i >= len(segmentsB) {
+			if cmp := compareTrailingSegment(segmentsA[i]); cmp != 0 {
+				return cmp
+			}
+			continue
+		}
+
+		numA, errA := strconv.Atoi(segmentsA[i])
+		numB, errB := strconv.Atoi(segmentsB[i])
+
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				return compareInts(numA, numB)
+			}
+		case errA == nil:
+			return 1 // Numbers sort after qualifiers, e.g. 1.0.1 > 1.0-beta
+		case errB == nil:
+			return -1
+		default:
+			if cmp := strings.Compare(strings.ToLower(segmentsA[i]), strings.ToLower(segmentsB[i])); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+
+	return 0
+}
+
+// compareTrailingSegment determines the order of two versions, of which only one
+// has the given segment left. Trailing zeros are insignificant, trailing numbers
+// make a version greater, and trailing qualifiers make it smaller.
+func compareTrailingSegment(segment string) int {
+	num, err := strconv.Atoi(segment)
+	switch {
+	case err != nil:
+		return -1
+	case num == 0:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// splitVersion splits a version into its numeric and alphabetic segments,
+// e.g. "2.0.0-beta9" into ["2", "0", "0", "beta", "9"].
+func splitVersion(version string) (segments []string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range version {
+		switch {
+		case r == '.' || r == '-' || r == '_' || r == '+':
+			flush()
+		case current.Len() > 0 && unicode.IsDigit(r) != isDigitString(current.String()):
+			flush()
+			current.WriteRune(r)
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return
+}
+
+func isDigitString(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,57 @@
+package dtrack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersionStrings(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.14.1", "2.14.1", 0},
+		{"2.14.1", "2.9.1", 1},
+		{"1.0", "1.0.0", 0},
+		{"1.0", "1.0.0.1", -1},
+		{"2.0.0-beta9", "2.0.0", -1},
+		{"2.0.0-beta9", "2.0.0-beta10", -1},
+		{"2.0.0-rc1", "2.0.0-beta9", 1},
+		{"v1.3.0", "1.3.0", 0},
+		{"1.0.1", "1.0-beta", 1},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, compareVersionStrings(tc.a, tc.b), "%s <=> %s", tc.a, tc.b)
+		require.Equal(t, -tc.expected, compareVersionStrings(tc.b, tc.a), "%s <=> %s", tc.b, tc.a)
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	vr, err := ParseVersionRange(">=2.0.0, <2.15.0 || >=2.16.0, <2.17.1")
+	require.NoError(t, err)
+
+	for version, contained := range map[string]bool{
+		"2.0.0-beta9": false,
+		"2.0.0":       true,
+		"2.14.1":      true,
+		"2.15.0":      false,
+		"2.16.0":      true,
+		"2.17.0":      true,
+		"2.17.1":      false,
+	} {
+		require.Equal(t, contained, vr.Contains(version), version)
+	}
+
+	vr, err = ParseVersionRange("1.2.3")
+	require.NoError(t, err)
+	require.True(t, vr.Contains("1.2.3"))
+	require.False(t, vr.Contains("1.2.4"))
+
+	_, err = ParseVersionRange(">=1.0 ||")
+	require.Error(t, err)
+
+	_, err = ParseVersionRange("<=")
+	require.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("4.10.1")
+	require.NoError(t, err)
+	require.Equal(t, Version{Major: 4, Minor: 10, Patch: 1}, v)
+
+	v, err = ParseVersion("4.0.0-SNAPSHOT")
+	require.NoError(t, err)
+	require.Equal(t, Version{Major: 4, PreRelease: "SNAPSHOT"}, v)
+	require.Equal(t, "4.0.0-SNAPSHOT", v.String())
+
+	v, err = ParseVersion("v4.7")
+	require.NoError(t, err)
+	require.Equal(t, Version{Major: 4, Minor: 7}, v)
+
+	_, err = ParseVersion("4.x.0")
+	require.Error(t, err)
+
+	_, err = ParseVersion("4.0.0.1")
+	require.Error(t, err)
+}
+
+func TestVersion_Compare(t *testing.T) {
+	require.Equal(t, -1, MustParseVersion("4.9.0").Compare(MustParseVersion("4.10.0")))
+	require.Equal(t, 1, MustParseVersion("4.10.0").Compare(MustParseVersion("4.9.1")))
+	require.Equal(t, 0, MustParseVersion("4.10.0").Compare(MustParseVersion("4.10")))
+	require.Equal(t, -1, MustParseVersion("4.10.0-SNAPSHOT").Compare(MustParseVersion("4.10.0")))
+	require.Equal(t, 1, MustParseVersion("4.10.0").Compare(MustParseVersion("4.10.0-SNAPSHOT")))
+
+	require.True(t, MustParseVersion("4.10.0-SNAPSHOT").AtLeast(MustParseVersion("4.10.0")))
+	require.False(t, MustParseVersion("4.4.2").AtLeast(MustParseVersion("4.5.0")))
+}
+
+func TestClient_ServerVersion(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version":"4.4.2"}`))
+
+	v, err := client.ServerVersion(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, MustParseVersion("4.4.2"), v)
+
+	// Version must be cached
+	v, err = client.ServerVersion(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, MustParseVersion("4.4.2"), v)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	_, err = client.VEX.ExportCycloneDX(context.TODO(), uuid.New())
+	require.ErrorIs(t, err, ErrUnsupportedServerVersion)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	var versionErr *UnsupportedServerVersionError
+	require.True(t, errors.As(err, &versionErr))
+	require.Equal(t, "VEX.ExportCycloneDX", versionErr.Operation)
+	require.Equal(t, MustParseVersion("4.5.0"), versionErr.RequiredVersion)
+	require.Equal(t, MustParseVersion("4.4.2"), versionErr.ServerVersion)
+}
+
+func TestWithServerVersion(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersion("4.5.0"))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.New()
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/vex/cyclonedx/project/"+projectUUID.String(),
+		httpmock.NewStringResponder(http.StatusOK, `{"bomFormat":"CycloneDX"}`))
+
+	vex, err := client.VEX.ExportCycloneDX(context.TODO(), projectUUID)
+	require.NoError(t, err)
+	require.Equal(t, `{"bomFormat":"CycloneDX"}`, vex)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
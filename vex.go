@@ -3,9 +3,12 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/google/uuid"
+
+	"github.com/nscuro/dtrack-client/bom"
 )
 
 type VEXService struct {
@@ -19,7 +22,15 @@ type VEXUploadRequest struct {
 	VEX            string     `json:"vex"`
 }
 
+// minVersionVEX is the minimum version of Dependency-Track that supports VEX.
+var minVersionVEX = Version{Major: 4, Minor: 5}
+
 func (vs VEXService) ExportCycloneDX(ctx context.Context, projectUUID uuid.UUID) (vex string, err error) {
+	err = vs.client.requireServerVersion(ctx, minVersionVEX)
+	if err != nil {
+		return
+	}
+
 	req, err := vs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/vex/cyclonedx/project/%s", projectUUID))
 	if err != nil {
 		return
@@ -31,7 +42,45 @@ func (vs VEXService) ExportCycloneDX(ctx context.Context, projectUUID uuid.UUID)
 	return
 }
 
+// ExportCycloneDXTo is like ExportCycloneDX, but streams the exported VEX to w instead of
+// reading it into memory. It returns the number of bytes written, and the content type
+// reported by the server.
+func (vs VEXService) ExportCycloneDXTo(ctx context.Context, projectUUID uuid.UUID, w io.Writer) (written int64, contentType string, err error) {
+	err = vs.client.requireServerVersion(ctx, minVersionVEX)
+	if err != nil {
+		return
+	}
+
+	req, err := vs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/vex/cyclonedx/project/%s", projectUUID))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Accept", "application/vnd.cyclonedx+json")
+
+	rw := responseWriter{w: w}
+	_, err = vs.client.doRequest(req, &rw)
+	written, contentType = rw.written, rw.contentType
+	return
+}
+
+// ExportCycloneDXDocument is like ExportCycloneDX, but decodes the exported VEX into a bom.Document.
+func (vs VEXService) ExportCycloneDXDocument(ctx context.Context, projectUUID uuid.UUID) (doc bom.Document, err error) {
+	vex, err := vs.ExportCycloneDX(ctx, projectUUID)
+	if err != nil {
+		return
+	}
+
+	doc, _, err = bom.Decode([]byte(vex))
+	return
+}
+
 func (vs VEXService) Upload(ctx context.Context, uploadReq VEXUploadRequest) (err error) {
+	err = vs.client.requireServerVersion(ctx, minVersionVEX)
+	if err != nil {
+		return
+	}
+
 	req, err := vs.client.newRequest(ctx, http.MethodPut, "/api/v1/vex", withBody(uploadReq))
 	if err != nil {
 		return
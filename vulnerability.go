@@ -34,6 +34,7 @@ type Vulnerability struct {
 	Severity                     string    `json:"severity"`
 	VulnerableVersions           string    `json:"vulnerableVersions"`
 	PatchedVersions              string    `json:"patchedVersions"`
+	AffectedProjectCount         int       `json:"affectedProjectCount,omitempty"`
 }
 
 type CWE struct {